@@ -5,7 +5,10 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"os"
+	"time"
 	"unicode/utf8"
 )
 
@@ -259,3 +262,503 @@ func byteAtATimeECBDecryption(oracle func([]byte) []byte) []byte {
 func ByteAtATimeECBDecryption(oracle func([]byte) []byte) string {
 	return base64.StdEncoding.EncodeToString(byteAtATimeECBDecryption(oracle))
 }
+
+// Returns the block size (in bytes) of the cipher used by a given encryption oracle, based on how the length of the
+// ciphertext grows with the length of the input (up to 64). If unsuccessful, returns -1.
+func findBlockSizeByLength(oracle func([]byte) []byte) int {
+	in := make([]byte, 0)
+	n := len(oracle(in))
+	for i := 0; i < 64; i++ {
+		in = append(in, 'A')
+		if m := len(oracle(in)); m > n {
+			return m - n
+		}
+	}
+	return -1
+}
+
+// Returns the offset at which a given ECB encryption oracle inserts its input into the plaintext, assuming that the
+// offset is fixed. If unsuccessful, returns -1.
+func findInputOffset(oracle func([]byte) []byte, blockSize int) int {
+	for pad := 0; pad < blockSize; pad++ {
+		in := bytes.Repeat([]byte{'A'}, pad+2*blockSize)
+		ciphertext := oracle(in)
+		for i := 0; i+2*blockSize <= len(ciphertext); i += blockSize {
+			if bytes.Equal(ciphertext[i:i+blockSize], ciphertext[i+blockSize:i+2*blockSize]) {
+				return i - pad
+			}
+		}
+	}
+	return -1
+}
+
+// Given an AES-128-ECB oracle which encrypts the profile of a user with a given email address, returns a ciphertext
+// whose plaintext is a profile with role=admin, built only from the oracle's outputs.
+func ecbCutAndPaste(oracle func([]byte) []byte) []byte {
+	blockSize := findBlockSizeByLength(oracle)
+	offset := findInputOffset(oracle, blockSize)
+	if blockSize < 0 || offset < 0 {
+		panic("ecbCutAndPaste: could not determine the oracle's block layout")
+	}
+
+	// Encrypt "admin" followed by its padding so that it lies in a block of its own.
+	pad := (blockSize - offset%blockSize) % blockSize
+	p := newPKCS(uint8(blockSize))
+	in := append(bytes.Repeat([]byte{'A'}, pad), p.pad([]byte("admin"))...)
+	adminIndex := offset + pad
+	adminBlock := oracle(in)[adminIndex : adminIndex+blockSize]
+
+	// Find the input length which makes the plaintext end exactly on a block boundary, then lengthen it so that "user"
+	// is pushed into a final block of its own.
+	n := len(oracle(nil))
+	k := 0
+	for len(oracle(bytes.Repeat([]byte{'A'}, k))) == n {
+		k++
+	}
+	ciphertext := oracle(bytes.Repeat([]byte{'A'}, k+len("user")))
+	forged := append([]byte{}, ciphertext[:len(ciphertext)-blockSize]...)
+	return append(forged, adminBlock...)
+}
+
+// Given an AES-128-ECB oracle which encrypts the profile of a user with a given email address, returns a base64-encoded
+// ciphertext whose plaintext is a profile with role=admin.
+func ECBCutAndPaste(oracle func([]byte) []byte) string {
+	return base64.StdEncoding.EncodeToString(ecbCutAndPaste(oracle))
+}
+
+// Returns the block size (in bytes) of the cipher used by a given ECB encryption oracle (between 8 and 32), even if the
+// oracle prepends a prefix of unknown (and possibly varying) length. Smaller sizes are not tried, since short chunks of
+// random ciphertext are too likely to repeat by chance. If unsuccessful, returns -1.
+func findBlockSizeWithPrefix(oracle func([]byte) []byte) int {
+	for s := 8; s <= 32; s++ {
+		// Any 3s identical bytes contain two full, aligned blocks of size s.
+		ciphertext := oracle(bytes.Repeat([]byte{'0'}, 3*s))
+		for i := 0; i+2*s <= len(ciphertext); i += s {
+			if bytes.Equal(ciphertext[i:i+s], ciphertext[i+s:i+2*s]) {
+				return s
+			}
+		}
+	}
+	return -1
+}
+
+// Returns a pair of distinct marker blocks. All bytes across both blocks are distinct, so that neither block is equal
+// to any rotation of itself or to a mix of the two.
+func markerBlocks(blockSize int) ([]byte, []byte) {
+	s, m := make([]byte, blockSize), make([]byte, blockSize)
+	for i := 0; i < blockSize; i++ {
+		s[i] = byte(0x80 + i)
+		m[i] = byte(0x80 + blockSize + i)
+	}
+	return s, m
+}
+
+// Returns the index just past a run of encrypted marker blocks S || M || M || S in ciphertext, or -1 if there is none.
+// The run only appears if the markers were aligned to a block boundary.
+func findMarkers(ciphertext []byte, blockSize int) int {
+	b := func(i int) []byte { return ciphertext[i*blockSize : (i+1)*blockSize] }
+	for i := 0; (i+4)*blockSize <= len(ciphertext); i++ {
+		if bytes.Equal(b(i), b(i+3)) && bytes.Equal(b(i+1), b(i+2)) && !bytes.Equal(b(i), b(i+1)) {
+			return (i + 4) * blockSize
+		}
+	}
+	return -1
+}
+
+// Given an ECB encryption oracle which prepends a prefix of unknown (and possibly varying) length to its input, returns
+// an oracle whose output begins at the block where its input begins, as if there were no prefix. This is done by
+// surrounding the input with marker blocks and retrying with different amounts of padding until the markers are
+// aligned.
+func stripPrefix(oracle func([]byte) []byte, blockSize int) func([]byte) []byte {
+	s, m := markerBlocks(blockSize)
+	markers := append(append(append(append([]byte{}, s...), m...), m...), s...)
+	pad := 0
+	return func(in []byte) []byte {
+		for attempt := 0; attempt < 64*blockSize; attempt++ {
+			marked := append(append(make([]byte, pad), markers...), in...)
+			ciphertext := oracle(marked)
+			if i := findMarkers(ciphertext, blockSize); i >= 0 {
+				return ciphertext[i:]
+			}
+			pad = (pad + 1) % blockSize
+		}
+		panic("stripPrefix: could not align the input")
+	}
+}
+
+// Given an AES-128-ECB encryption oracle which encrypts a random prefix followed by a given string followed by a fixed
+// string using a fixed key, returns the fixed string. The prefix may be fixed or different on every call.
+func byteAtATimeECBDecryptionWithPrefix(oracle func([]byte) []byte) []byte {
+	if !ECBCBCDetectionOracle(oracle) {
+		panic("Oracle is not using ECB mode")
+	}
+
+	blockSize := findBlockSizeWithPrefix(oracle)
+	if blockSize < 0 {
+		panic("Could not determine the oracle's block size")
+	}
+
+	aligned := stripPrefix(oracle, blockSize)
+	saltLen := findSaltLength(aligned, blockSize)
+	salt := make([]byte, 0)
+	for len(salt) < saltLen {
+		salt = append(salt, getBlock(aligned, salt, blockSize, saltLen)...)
+	}
+	return salt
+}
+
+// Given an AES-128-ECB encryption oracle which encrypts a random prefix followed by a given string followed by a fixed
+// string using a fixed key, returns the fixed string.
+func ByteAtATimeECBDecryptionWithPrefix(oracle func([]byte) []byte) string {
+	return base64.StdEncoding.EncodeToString(byteAtATimeECBDecryptionWithPrefix(oracle))
+}
+
+// Returns the offset at which a given block cipher encryption oracle inserts its input into the plaintext, by finding
+// how many bytes of input are needed to push a change in the input into the next block. If unsuccessful, returns -1.
+func findBlockInputOffset(oracle func([]byte) []byte, blockSize int) int {
+	firstDiff := func(n int) int {
+		c1 := oracle(append(bytes.Repeat([]byte{'A'}, n), 'B'))
+		c2 := oracle(append(bytes.Repeat([]byte{'A'}, n), 'C'))
+		for i := 0; i < len(c1) && i < len(c2); i += blockSize {
+			if !bytes.Equal(c1[i:i+blockSize], c2[i:i+blockSize]) {
+				return i / blockSize
+			}
+		}
+		return -1
+	}
+
+	first := firstDiff(0)
+	if first < 0 {
+		return -1
+	}
+	for n := 1; n <= blockSize; n++ {
+		if firstDiff(n) > first {
+			return (first+1)*blockSize - n
+		}
+	}
+	return -1
+}
+
+// Returns a copy of a ciphertext in which the bytes starting at offset have been XORed with known ^ desired. For a
+// stream cipher, this turns known plaintext at offset into desired; for CBC, it does the same to the next block.
+func flipBytes(ciphertext []byte, offset int, known, desired []byte) ([]byte, error) {
+	if len(known) != len(desired) {
+		return nil, errors.New("flipBytes: known and desired plaintexts must be of the same length")
+	}
+
+	if offset < 0 || offset+len(known) > len(ciphertext) {
+		return nil, fmt.Errorf("flipBytes: offset %d is outside the ciphertext", offset)
+	}
+
+	modified := append([]byte{}, ciphertext...)
+	for i := range known {
+		modified[offset+i] ^= known[i] ^ desired[i]
+	}
+	return modified, nil
+}
+
+// Returns a copy of a CBC ciphertext, modified so that the plaintext block at index target, which is known to begin
+// with known, instead begins with desired. The block before the target decrypts to garbage.
+func cbcBitflip(ciphertext []byte, blockSize, target int, known, desired []byte) ([]byte, error) {
+	if len(ciphertext)%blockSize != 0 {
+		return nil, fmt.Errorf("cbcBitflip: %w", ErrMisalignedInput)
+	}
+
+	if target < 1 || target >= len(ciphertext)/blockSize {
+		return nil, errors.New("cbcBitflip: target block must be preceded by a ciphertext block")
+	}
+
+	if len(known) > blockSize {
+		return nil, errors.New("cbcBitflip: known and desired plaintexts must be at most one block long")
+	}
+
+	return flipBytes(ciphertext, (target-1)*blockSize, known, desired)
+}
+
+// Given an AES-128-CBC oracle which quotes and wraps user data between fixed comment strings before encrypting it,
+// returns a ciphertext whose plaintext contains ";admin=true;".
+func cbcBitflipping(oracle func([]byte) []byte) ([]byte, error) {
+	blockSize := findBlockSizeByLength(oracle)
+	if blockSize < 0 {
+		return nil, errors.New("cbcBitflipping: could not determine the oracle's block size")
+	}
+
+	offset := findBlockInputOffset(oracle, blockSize)
+	if offset < 0 {
+		return nil, errors.New("cbcBitflipping: could not determine the oracle's input offset")
+	}
+
+	// Send two blocks of known input: the first is scrambled to flip the second.
+	pad := (blockSize - offset%blockSize) % blockSize
+	known := bytes.Repeat([]byte{'A'}, blockSize)
+	desired := append([]byte(";admin=true;"), known...)[:blockSize]
+	ciphertext := oracle(bytes.Repeat([]byte{'A'}, pad+2*blockSize))
+	target := (offset+pad)/blockSize + 1
+	return cbcBitflip(ciphertext, blockSize, target, known, desired)
+}
+
+// Given an AES-128-CBC oracle which quotes and wraps user data between fixed comment strings before encrypting it,
+// returns a base64-encoded ciphertext whose plaintext contains ";admin=true;".
+func CBCBitflipping(oracle func([]byte) []byte) (string, error) {
+	ciphertext, err := cbcBitflipping(oracle)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Given a padding oracle and a ciphertext block, returns the block's intermediate state (its decryption before being
+// XORed with the previous block), along with the number of oracle queries used.
+func paddingOracleBlock(oracle func([]byte, []byte) bool, block []byte) ([]byte, int, error) {
+	blockSize := len(block)
+	intermediate := make([]byte, blockSize)
+	forged := make([]byte, blockSize)
+	queries := 0
+	for pos := blockSize - 1; pos >= 0; pos-- {
+		padVal := byte(blockSize - pos)
+		for j := pos + 1; j < blockSize; j++ {
+			forged[j] = intermediate[j] ^ padVal
+		}
+
+		found := false
+		for g := 0; g < 256; g++ {
+			forged[pos] = byte(g)
+			queries++
+			if !oracle(forged, block) {
+				continue
+			}
+
+			// For the last byte, valid padding could also be e.g. \x02\x02. Changing the second-to-last byte breaks
+			// any such padding but leaves \x01 valid.
+			if pos == blockSize-1 && pos > 0 {
+				forged[pos-1] ^= 0xff
+				queries++
+				valid := oracle(forged, block)
+				forged[pos-1] ^= 0xff
+				if !valid {
+					continue
+				}
+			}
+
+			intermediate[pos] = byte(g) ^ padVal
+			found = true
+			break
+		}
+		if !found {
+			return nil, queries, fmt.Errorf("paddingOracleBlock: no valid padding found for byte %d", pos)
+		}
+	}
+	return intermediate, queries, nil
+}
+
+// Given a CBC padding oracle, which reports whether the decryption of a given (IV, ciphertext) pair has valid PKCS#7
+// padding, returns the unpadded plaintext corresponding to a given IV and ciphertext, along with the number of oracle
+// queries used.
+func paddingOracleAttack(oracle func([]byte, []byte) bool, iv, ciphertext []byte) ([]byte, int, error) {
+	blockSize := len(iv)
+	if blockSize == 0 || len(ciphertext)%blockSize != 0 {
+		return nil, 0, fmt.Errorf("paddingOracleAttack: %w", ErrMisalignedInput)
+	}
+
+	var plaintext []byte
+	queries := 0
+	prev := iv
+	for i := 0; i < len(ciphertext); i += blockSize {
+		block := ciphertext[i : i+blockSize]
+		intermediate, n, err := paddingOracleBlock(oracle, block)
+		queries += n
+		if err != nil {
+			return nil, queries, err
+		}
+
+		plaintextBlock, err := fixedXOR(intermediate, prev)
+		if err != nil {
+			return nil, queries, err
+		}
+		plaintext = append(plaintext, plaintextBlock...)
+		prev = block
+	}
+
+	p := newPKCS(uint8(blockSize))
+	plaintext, err := p.unpad(plaintext)
+	return plaintext, queries, err
+}
+
+// Given a CBC padding oracle, which reports whether the decryption of a given (IV, ciphertext) pair has valid PKCS#7
+// padding, returns the base64-encoded plaintext corresponding to a given IV and ciphertext, along with the number of
+// oracle queries used.
+func PaddingOracleAttack(oracle func([]byte, []byte) bool, iv, ciphertext []byte) (string, int, error) {
+	plaintext, queries, err := paddingOracleAttack(oracle, iv, ciphertext)
+	if err != nil {
+		return "", queries, err
+	}
+	return base64.StdEncoding.EncodeToString(plaintext), queries, nil
+}
+
+// Returns a guess of the (keystream, plaintexts) pair corresponding to ciphertexts, assuming that they have all been
+// XORed against the same keystream, e.g. by CTR mode with a fixed nonce. Each column of the keystream is guessed
+// independently from the ciphertexts which are long enough to reach it, so the ciphertexts need not be of equal length.
+func breakFixedNonceCTR(ciphertexts [][]byte) ([]byte, [][]byte, error) {
+	maxLen := 0
+	for _, c := range ciphertexts {
+		if len(c) > maxLen {
+			maxLen = len(c)
+		}
+	}
+
+	keystream := make([]byte, maxLen)
+	for i := 0; i < maxLen; i++ {
+		var column []byte
+		for _, c := range ciphertexts {
+			if i < len(c) {
+				column = append(column, c[i])
+			}
+		}
+		b, _, _, err := breakSingleByteXOR(column)
+		if err != nil {
+			return nil, nil, err
+		}
+		keystream[i] = b
+	}
+
+	plaintexts := make([][]byte, len(ciphertexts))
+	for i, c := range ciphertexts {
+		plaintext, err := fixedXOR(c, keystream[:len(c)])
+		if err != nil {
+			return nil, nil, err
+		}
+		plaintexts[i] = plaintext
+	}
+	return keystream, plaintexts, nil
+}
+
+// Returns a guess of the (keystream, plaintexts) pair corresponding to ciphertexts, assuming that they have all been
+// XORed against the same keystream, e.g. by CTR mode with a fixed nonce.
+func BreakFixedNonceCTR(ciphertexts [][]byte) ([]byte, [][]byte, error) {
+	return breakFixedNonceCTR(ciphertexts)
+}
+
+// Given a file with one base64-encoded ciphertext per line, returns a guess of the (keystream, plaintexts) pair,
+// assuming that the ciphertexts have all been XORed against the same keystream.
+func BreakFixedNonceCTRFile(file *os.File) ([]byte, [][]byte, error) {
+	ciphertexts, err := base64LinesToBytes(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return breakFixedNonceCTR(ciphertexts)
+}
+
+// Given an MT19937 stream cipher encryption oracle which prepends random bytes to a plaintext before encrypting it,
+// returns the oracle's 16-bit key.
+func RecoverMTStreamKey(oracle func([]byte) []byte) (uint16, error) {
+	known := bytes.Repeat([]byte{'A'}, 14)
+	ciphertext := oracle(known)
+	prefixLen := len(ciphertext) - len(known)
+	for key := 0; key < 1<<16; key++ {
+		keystream := mtKeystream(uint32(key), len(ciphertext))
+		plaintext, err := fixedXOR(ciphertext[prefixLen:], keystream[prefixLen:])
+		if err != nil {
+			return 0, err
+		}
+
+		if bytes.Equal(plaintext, known) {
+			return uint16(key), nil
+		}
+	}
+	return 0, errors.New("RecoverMTStreamKey: no 16-bit key produces the known plaintext")
+}
+
+// Returns true iff a given token was taken from the keystream of an MT19937 seeded with a Unix time within window of
+// now.
+func IsTimeSeededMTToken(token []byte, now time.Time, window time.Duration) bool {
+	for t := now.Unix(); t >= now.Add(-window).Unix(); t-- {
+		if bytes.Equal(token, mtKeystream(uint32(t), len(token))) {
+			return true
+		}
+	}
+	return false
+}
+
+// Given a CTR ciphertext and an edit oracle which replaces its plaintext starting at a given offset, returns the
+// plaintext. Editing in zeros makes the oracle return the keystream itself.
+func breakRandomAccessCTR(ciphertext []byte, edit func([]byte, int, []byte) ([]byte, error)) ([]byte, error) {
+	keystream, err := edit(ciphertext, 0, make([]byte, len(ciphertext)))
+	if err != nil {
+		return nil, err
+	}
+	return fixedXOR(ciphertext, keystream)
+}
+
+// Given a CTR ciphertext and an edit oracle which replaces its plaintext starting at a given offset, returns the
+// base64-encoded plaintext.
+func BreakRandomAccessCTR(ciphertext []byte, edit func([]byte, int, []byte) ([]byte, error)) (string, error) {
+	plaintext, err := breakRandomAccessCTR(ciphertext, edit)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(plaintext), nil
+}
+
+// Given an AES-128-CTR oracle which quotes and wraps user data between fixed comment strings before encrypting it,
+// returns a ciphertext whose plaintext contains ";admin=true;".
+func ctrBitflipping(oracle func([]byte) []byte) ([]byte, error) {
+	// Since CTR encrypts byte by byte, the first byte at which two ciphertexts differ is where the input starts.
+	c1, c2 := oracle([]byte{'A'}), oracle([]byte{'B'})
+	offset := -1
+	for i := 0; i < len(c1) && i < len(c2); i++ {
+		if c1[i] != c2[i] {
+			offset = i
+			break
+		}
+	}
+	if offset < 0 {
+		return nil, errors.New("ctrBitflipping: could not determine the oracle's input offset")
+	}
+
+	desired := []byte(";admin=true;")
+	known := bytes.Repeat([]byte{'A'}, len(desired))
+	return flipBytes(oracle(known), offset, known, desired)
+}
+
+// Given an AES-128-CTR oracle which quotes and wraps user data between fixed comment strings before encrypting it,
+// returns a base64-encoded ciphertext whose plaintext contains ";admin=true;".
+func CTRBitflipping(oracle func([]byte) []byte) (string, error) {
+	ciphertext, err := ctrBitflipping(oracle)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Given an AES-128-CBC encryption oracle which uses its key as the IV, and a decryption oracle which leaks the
+// plaintext through a *HighASCIIError, returns the key. Decrypting C1 || 0 || C1 gives P1 and D(C1), and the key is
+// their XOR.
+func recoverKeyAsIV(encrypt func([]byte) []byte, check func([]byte) error) ([]byte, error) {
+	const blockSize = 16
+	ciphertext := encrypt(bytes.Repeat([]byte{'A'}, 3*blockSize))
+	if len(ciphertext) < 3*blockSize {
+		return nil, errors.New("recoverKeyAsIV: ciphertext is shorter than three blocks")
+	}
+
+	c1 := ciphertext[:blockSize]
+	modified := append(append(append([]byte{}, c1...), make([]byte, blockSize)...), c1...)
+	modified = append(modified, ciphertext[3*blockSize:]...)
+
+	var asciiErr *HighASCIIError
+	if err := check(modified); !errors.As(err, &asciiErr) {
+		return nil, fmt.Errorf("recoverKeyAsIV: decryption oracle did not leak the plaintext: %v", err)
+	}
+
+	p := asciiErr.Plaintext
+	return fixedXOR(p[:blockSize], p[2*blockSize:3*blockSize])
+}
+
+// Given an AES-128-CBC encryption oracle which uses its key as the IV, and a decryption oracle which leaks the
+// plaintext through a *HighASCIIError, returns the base64-encoded key.
+func RecoverKeyAsIV(encrypt func([]byte) []byte, check func([]byte) error) (string, error) {
+	key, err := recoverKeyAsIV(encrypt, check)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
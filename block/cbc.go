@@ -103,6 +103,14 @@ func aesCBC(ciphertext, key, iv []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	if err := checkIV("aesCBC", block, iv); err != nil {
+		return nil, err
+	}
+
+	if err := checkAligned("aesCBC", block, ciphertext); err != nil {
+		return nil, err
+	}
+
 	decrypter := newCBCDecrypter(block, iv)
 	plaintext := make([]byte, len(ciphertext))
 	decrypter.CryptBlocks(plaintext, ciphertext)
@@ -110,6 +118,37 @@ func aesCBC(ciphertext, key, iv []byte) ([]byte, error) {
 	return plaintext, nil
 }
 
+// Pads a given plaintext according to PKCS#7 and encrypts it using a given AES-128 key and IV in CBC mode.
+func aesCBCEncrypt(plaintext, key, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkIV("aesCBCEncrypt", block, iv); err != nil {
+		return nil, err
+	}
+
+	p := newPKCS(uint8(block.BlockSize()))
+	paddedPlaintext := p.pad(plaintext)
+	encrypter := newCBCEncrypter(block, iv)
+	ciphertext := make([]byte, len(paddedPlaintext))
+	encrypter.CryptBlocks(ciphertext, paddedPlaintext)
+
+	return ciphertext, nil
+}
+
+// Decrypts a given ciphertext using a given AES-128 key and IV in CBC mode and removes the PKCS#7 padding.
+func aesCBCDecrypt(ciphertext, key, iv []byte) ([]byte, error) {
+	paddedPlaintext, err := aesCBC(ciphertext, key, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPKCS(aes.BlockSize)
+	return p.unpad(paddedPlaintext)
+}
+
 // Decrypts a base64-encoded file with AES-128 in ECB mode and returns the result as a base64-encoded string.
 func AESECB(file *os.File, key string) (string, error) {
 	rawCiphertext, err := base64FileToBytes(file)
@@ -154,3 +193,67 @@ func AESCBC(file *os.File, key, iv string) (string, error) {
 
 	return base64.StdEncoding.EncodeToString(rawPlaintext), nil
 }
+
+// Encrypts a raw plaintext with AES-128 in CBC mode under a given key and IV, after applying PKCS#7 padding. See
+// EncryptAESCBC for the base64 version.
+func EncryptAESCBCBytes(plaintext, key, iv []byte) ([]byte, error) {
+	return aesCBCEncrypt(plaintext, key, iv)
+}
+
+// Decrypts a raw ciphertext with AES-128 in CBC mode under a given key and IV, and removes the PKCS#7 padding. See
+// DecryptAESCBC for the base64 version.
+func DecryptAESCBCBytes(ciphertext, key, iv []byte) ([]byte, error) {
+	return aesCBCDecrypt(ciphertext, key, iv)
+}
+
+// Encrypts a base64-encoded plaintext with AES-128 in CBC mode, after applying PKCS#7 padding, and returns the result as
+// a base64-encoded string.
+func EncryptAESCBC(plaintext, key, iv string) (string, error) {
+	rawPlaintext, err := base64.StdEncoding.DecodeString(plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+
+	rawIV, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return "", err
+	}
+
+	rawCiphertext, err := aesCBCEncrypt(rawPlaintext, rawKey, rawIV)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(rawCiphertext), nil
+}
+
+// Decrypts a base64-encoded ciphertext with AES-128 in CBC mode, removes the PKCS#7 padding, and returns the result as a
+// base64-encoded string.
+func DecryptAESCBC(ciphertext, key, iv string) (string, error) {
+	rawCiphertext, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+
+	rawIV, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return "", err
+	}
+
+	rawPlaintext, err := aesCBCDecrypt(rawCiphertext, rawKey, rawIV)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(rawPlaintext), nil
+}
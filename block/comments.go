@@ -0,0 +1,30 @@
+package block
+
+import (
+	"bytes"
+	"strings"
+)
+
+// This file provides the semicolon-separated comment format used by the bitflipping challenges.
+
+const (
+	commentPrefix = "comment1=cooking%20MCs;userdata="
+	commentSuffix = ";comment2=%20like%20a%20pound%20of%20bacon"
+)
+
+var commentQuoter = strings.NewReplacer("%", "%25", ";", "%3B", "=", "%3D")
+
+// Returns userdata with its metacharacters quoted, wrapped between the fixed comment strings.
+func wrapUserData(userdata []byte) []byte {
+	return []byte(commentPrefix + commentQuoter.Replace(string(userdata)) + commentSuffix)
+}
+
+// Returns true iff plaintext contains the field "admin=true".
+func isAdmin(plaintext []byte) bool {
+	for _, field := range bytes.Split(plaintext, []byte(";")) {
+		if string(field) == "admin=true" {
+			return true
+		}
+	}
+	return false
+}
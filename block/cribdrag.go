@@ -0,0 +1,135 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// This file provides crib dragging for many-time-pad ciphertexts, i.e., ciphertexts which have all been XORed against
+// the same keystream. It is meant to clean up the last few bytes which breakFixedNonceCTR gets wrong.
+
+// A CribDragger holds a set of ciphertexts and a shared guess of their keystream, which can be refined one crib at a
+// time.
+type CribDragger struct {
+	ciphertexts [][]byte
+	keystream   []byte
+}
+
+// A CribMatch is one placement of a crib in one ciphertext, along with the text it reveals in another ciphertext and
+// that text's score according to scorePlaintext.
+type CribMatch struct {
+	Offset   int
+	Revealed []byte
+	Score    int
+}
+
+// Returns a CribDragger for the given ciphertexts, starting from a given keystream guess. The keystream is extended with
+// zeros if it is shorter than the longest ciphertext.
+func NewCribDragger(ciphertexts [][]byte, keystream []byte) *CribDragger {
+	maxLen := len(keystream)
+	for _, c := range ciphertexts {
+		if len(c) > maxLen {
+			maxLen = len(c)
+		}
+	}
+
+	d := &CribDragger{
+		ciphertexts: make([][]byte, len(ciphertexts)),
+		keystream:   make([]byte, maxLen),
+	}
+	for i, c := range ciphertexts {
+		d.ciphertexts[i] = append([]byte{}, c...)
+	}
+	copy(d.keystream, keystream)
+	return d
+}
+
+// Given a file with one base64-encoded ciphertext per line, returns a CribDragger whose keystream is initially guessed
+// statistically by breakFixedNonceCTR.
+func NewCribDraggerFromFile(file *os.File) (*CribDragger, error) {
+	ciphertexts, err := base64LinesToBytes(file)
+	if err != nil {
+		return nil, err
+	}
+
+	keystream, _, err := breakFixedNonceCTR(ciphertexts)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCribDragger(ciphertexts, keystream), nil
+}
+
+// Returns an error if i is not the index of a ciphertext.
+func (d *CribDragger) checkIndex(i int) error {
+	if i < 0 || i >= len(d.ciphertexts) {
+		return fmt.Errorf("CribDragger: no ciphertext with index %d", i)
+	}
+	return nil
+}
+
+// Slides crib across the XOR of ciphertexts i and j, assuming at each offset that it is part of plaintext i, and
+// returns the text revealed in plaintext j at each offset, best first.
+func (d *CribDragger) Drag(i, j int, crib []byte) ([]CribMatch, error) {
+	if err := d.checkIndex(i); err != nil {
+		return nil, err
+	}
+
+	if err := d.checkIndex(j); err != nil {
+		return nil, err
+	}
+
+	if len(crib) == 0 {
+		return nil, errors.New("CribDragger: empty crib")
+	}
+
+	n := len(d.ciphertexts[i])
+	if len(d.ciphertexts[j]) < n {
+		n = len(d.ciphertexts[j])
+	}
+
+	var matches []CribMatch
+	for offset := 0; offset+len(crib) <= n; offset++ {
+		revealed := make([]byte, len(crib))
+		for k := range crib {
+			revealed[k] = d.ciphertexts[i][offset+k] ^ d.ciphertexts[j][offset+k] ^ crib[k]
+		}
+		matches = append(matches, CribMatch{offset, revealed, scorePlaintext(revealed)})
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].Score > matches[b].Score })
+	return matches, nil
+}
+
+// Commits a guess that plaintext i contains guess at offset, updating the shared keystream and hence all of the other
+// plaintexts.
+func (d *CribDragger) Commit(i, offset int, guess []byte) error {
+	if err := d.checkIndex(i); err != nil {
+		return err
+	}
+
+	if offset < 0 || offset+len(guess) > len(d.ciphertexts[i]) {
+		return fmt.Errorf("CribDragger: guess does not fit in ciphertext %d at offset %d", i, offset)
+	}
+
+	for k := range guess {
+		d.keystream[offset+k] = d.ciphertexts[i][offset+k] ^ guess[k]
+	}
+	return nil
+}
+
+// Returns the current keystream guess.
+func (d *CribDragger) Keystream() []byte {
+	return append([]byte{}, d.keystream...)
+}
+
+// Returns the plaintexts corresponding to the current keystream guess.
+func (d *CribDragger) Plaintexts() [][]byte {
+	plaintexts := make([][]byte, len(d.ciphertexts))
+	for i, c := range d.ciphertexts {
+		plaintexts[i] = repeatingKeyXOR(c, d.keystream[:len(c)])
+	}
+	return plaintexts
+}
@@ -0,0 +1,232 @@
+package block
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"os"
+)
+
+// This file provides an implementation of the CTR stream cipher mode. As with ecb.go and cbc.go, the organization is
+// similar to that of Go's crypto/cipher package, but the implementation is done "from scratch".
+
+// CTRLayout describes how the counter block is laid out and incremented.
+type CTRLayout int
+
+const (
+	// CTRNonceCounterLE treats the counter block as a 64-bit nonce followed by a 64-bit little-endian block counter,
+	// as in the Cryptopals challenges.
+	CTRNonceCounterLE CTRLayout = iota
+	// CTRCounterBE treats the whole counter block as a single big-endian counter, as in NIST SP 800-38A.
+	CTRCounterBE
+)
+
+type ctr struct {
+	b         cipher.Block
+	blockSize int
+	layout    CTRLayout
+	counter   []byte
+	keystream []byte
+	used      int
+}
+
+func newCTR(b cipher.Block, iv []byte, layout CTRLayout) cipher.Stream {
+	if len(iv) != b.BlockSize() {
+		panic("CTR mode: IV length is not equal to block size")
+	}
+
+	if layout == CTRNonceCounterLE && b.BlockSize() != 16 {
+		panic("CTR mode: nonce/counter layout requires a 16-byte block size")
+	}
+
+	counter := make([]byte, len(iv))
+	copy(counter, iv)
+	return &ctr{
+		b:         b,
+		blockSize: b.BlockSize(),
+		layout:    layout,
+		counter:   counter,
+		keystream: make([]byte, b.BlockSize()),
+		used:      b.BlockSize(),
+	}
+}
+
+// Returns a CTR stream positioned at a given byte offset into the keystream, so that part of a message can be
+// encrypted or decrypted without processing what comes before it.
+func newCTRAt(b cipher.Block, iv []byte, layout CTRLayout, offset int) cipher.Stream {
+	if offset < 0 {
+		panic("CTR mode: negative offset")
+	}
+
+	s := newCTR(b, iv, layout).(*ctr)
+	s.advance(uint64(offset / s.blockSize))
+	s.refill()
+	s.used = offset % s.blockSize
+	return s
+}
+
+// Advances the counter block by n according to the layout.
+func (s *ctr) advance(n uint64) {
+	switch s.layout {
+	case CTRNonceCounterLE:
+		c := binary.LittleEndian.Uint64(s.counter[8:])
+		binary.LittleEndian.PutUint64(s.counter[8:], c+n)
+	case CTRCounterBE:
+		for i := len(s.counter) - 1; i >= 0 && n > 0; i-- {
+			sum := uint64(s.counter[i]) + n&0xff
+			s.counter[i] = byte(sum)
+			n = n>>8 + sum>>8
+		}
+	default:
+		panic("CTR mode: unknown counter layout")
+	}
+}
+
+// Encrypts the current counter block into the keystream buffer and advances the counter.
+func (s *ctr) refill() {
+	s.b.Encrypt(s.keystream, s.counter)
+	s.used = 0
+	s.advance(1)
+}
+
+func (s *ctr) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("CTR XORKeyStream: output smaller than input")
+	}
+
+	for i := range src {
+		if s.used == s.blockSize {
+			s.refill()
+		}
+		dst[i] = src[i] ^ s.keystream[s.used]
+		s.used++
+	}
+}
+
+// Encrypts or decrypts a given input using a given AES-128 key, initial counter block, and counter layout in CTR mode.
+func aesCTR(input, key, iv []byte, layout CTRLayout) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkIV("aesCTR", block, iv); err != nil {
+		return nil, err
+	}
+
+	stream := newCTR(block, iv, layout)
+	output := make([]byte, len(input))
+	stream.XORKeyStream(output, input)
+
+	return output, nil
+}
+
+// Returns a copy of a given CTR ciphertext in which the plaintext starting at offset has been replaced by newtext,
+// re-encrypting only the edited bytes. The ciphertext is extended if newtext runs past its end.
+func ctrEdit(ciphertext, key, iv []byte, layout CTRLayout, offset int, newtext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkIV("ctrEdit", block, iv); err != nil {
+		return nil, err
+	}
+
+	if offset < 0 || offset > len(ciphertext) {
+		return nil, fmt.Errorf("ctrEdit: offset %d is outside the ciphertext", offset)
+	}
+
+	edited := append([]byte{}, ciphertext...)
+	if end := offset + len(newtext); end > len(edited) {
+		edited = append(edited, make([]byte, end-len(edited))...)
+	}
+	newCTRAt(block, iv, layout, offset).XORKeyStream(edited[offset:], newtext)
+
+	return edited, nil
+}
+
+// Encrypts or decrypts a base64-encoded file with AES-128 in CTR mode and returns the result as a base64-encoded string.
+// The IV is the base64-encoded initial counter block, which is incremented according to layout.
+func AESCTR(file *os.File, key, iv string, layout CTRLayout) (string, error) {
+	rawInput, err := base64FileToBytes(file)
+	if err != nil {
+		return "", err
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+
+	rawIV, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return "", err
+	}
+
+	rawOutput, err := aesCTR(rawInput, rawKey, rawIV, layout)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(rawOutput), nil
+}
+
+// Encrypts or decrypts a base64-encoded input with AES-128 in CTR mode and returns the result as a base64-encoded string.
+// The IV is the base64-encoded initial counter block, which is incremented according to layout.
+func AESCTRString(input, key, iv string, layout CTRLayout) (string, error) {
+	rawInput, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return "", err
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+
+	rawIV, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return "", err
+	}
+
+	rawOutput, err := aesCTR(rawInput, rawKey, rawIV, layout)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(rawOutput), nil
+}
+
+// Replaces the plaintext starting at a given offset of a base64-encoded AES-128-CTR ciphertext with a base64-encoded
+// newtext and returns the edited ciphertext as a base64-encoded string.
+func AESCTREdit(ciphertext, key, iv string, layout CTRLayout, offset int, newtext string) (string, error) {
+	rawCiphertext, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+
+	rawIV, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return "", err
+	}
+
+	rawNewtext, err := base64.StdEncoding.DecodeString(newtext)
+	if err != nil {
+		return "", err
+	}
+
+	rawEdited, err := ctrEdit(rawCiphertext, rawKey, rawIV, layout, offset, rawNewtext)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(rawEdited), nil
+}
@@ -3,6 +3,7 @@ package block
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"encoding/base64"
 )
 
 // This file provides an implementation of the ECB block cipher mode. The organization is similar to that of Go's
@@ -75,9 +76,82 @@ func aesECB(ciphertext, key []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	if err := checkAligned("aesECB", block, ciphertext); err != nil {
+		return nil, err
+	}
+
 	decrypter := newECBDecrypter(block)
 	plaintext := make([]byte, len(ciphertext))
 	decrypter.CryptBlocks(plaintext, ciphertext)
 
 	return plaintext, nil
 }
+
+// Pads a given plaintext according to PKCS#7 and encrypts it using a given AES-128 key in ECB mode.
+func aesECBEncrypt(plaintext, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPKCS(uint8(block.BlockSize()))
+	paddedPlaintext := p.pad(plaintext)
+	encrypter := newECBEncrypter(block)
+	ciphertext := make([]byte, len(paddedPlaintext))
+	encrypter.CryptBlocks(ciphertext, paddedPlaintext)
+
+	return ciphertext, nil
+}
+
+// Decrypts a given ciphertext using a given AES-128 key in ECB mode and removes the PKCS#7 padding.
+func aesECBDecrypt(ciphertext, key []byte) ([]byte, error) {
+	paddedPlaintext, err := aesECB(ciphertext, key)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPKCS(aes.BlockSize)
+	return p.unpad(paddedPlaintext)
+}
+
+// Encrypts a base64-encoded plaintext with AES-128 in ECB mode, after applying PKCS#7 padding, and returns the result as
+// a base64-encoded string.
+func EncryptAESECB(plaintext, key string) (string, error) {
+	rawPlaintext, err := base64.StdEncoding.DecodeString(plaintext)
+	if err != nil {
+		return "", err
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+
+	rawCiphertext, err := aesECBEncrypt(rawPlaintext, rawKey)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(rawCiphertext), nil
+}
+
+// Decrypts a base64-encoded ciphertext with AES-128 in ECB mode, removes the PKCS#7 padding, and returns the result as a
+// base64-encoded string.
+func DecryptAESECB(ciphertext, key string) (string, error) {
+	rawCiphertext, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", err
+	}
+
+	rawPlaintext, err := aesECBDecrypt(rawCiphertext, rawKey)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(rawPlaintext), nil
+}
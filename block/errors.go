@@ -0,0 +1,44 @@
+package block
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by the padding and block mode helpers. Callers should compare against these using errors.Is,
+// since they are usually wrapped with the name of the function that produced them.
+var (
+	// ErrInvalidPadding indicates that an input does not end with valid PKCS#7 padding.
+	ErrInvalidPadding = errors.New("invalid PKCS#7 padding")
+	// ErrMisalignedInput indicates that an input's length is not a multiple of the cipher's block size.
+	ErrMisalignedInput = errors.New("input length is not a multiple of the block size")
+	// ErrInvalidIVLength indicates that an IV's length is not equal to the cipher's block size.
+	ErrInvalidIVLength = errors.New("IV length is not equal to the block size")
+)
+
+// Returns an error wrapping ErrMisalignedInput if the length of input is not a multiple of the block size of b.
+func checkAligned(name string, b cipher.Block, input []byte) error {
+	if len(input)%b.BlockSize() != 0 {
+		return fmt.Errorf("%s: %w", name, ErrMisalignedInput)
+	}
+	return nil
+}
+
+// Returns an error wrapping ErrInvalidIVLength if the length of iv is not equal to the block size of b.
+func checkIV(name string, b cipher.Block, iv []byte) error {
+	if len(iv) != b.BlockSize() {
+		return fmt.Errorf("%s: %w", name, ErrInvalidIVLength)
+	}
+	return nil
+}
+
+// HighASCIIError is returned by a decryption oracle when the plaintext contains bytes outside of the ASCII range. It
+// carries the offending plaintext, as a careless server might include it in an error message.
+type HighASCIIError struct {
+	Plaintext []byte
+}
+
+func (e *HighASCIIError) Error() string {
+	return fmt.Sprintf("plaintext contains high-ASCII bytes: %q", e.Plaintext)
+}
@@ -1,6 +1,7 @@
 package block
 
 import (
+	"bufio"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
@@ -53,6 +54,20 @@ func base64FileToBytes(file *os.File) ([]byte, error) {
 	return rawBytes[:bytesRead], nil
 }
 
+// Decodes each line of a file of base64-encoded lines into a byte slice.
+func base64LinesToBytes(file *os.File) ([][]byte, error) {
+	var lines [][]byte
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		rawLine, err := base64.StdEncoding.DecodeString(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, rawLine)
+	}
+	return lines, scanner.Err()
+}
+
 // Counts the number of repeated substrings (counting all occurrences) of length size in rawBytes
 func countRepeats(rawBytes []byte, size int) int {
 	count := 0
@@ -0,0 +1,60 @@
+package block
+
+import (
+	"crypto/cipher"
+	"cryptopals/prng"
+	"encoding/base64"
+	"encoding/binary"
+)
+
+// This file provides a stream cipher whose keystream is the output of an MT19937, in the same form as Go's
+// crypto/cipher streams.
+
+type mtStream struct {
+	mt        *prng.MT19937
+	keystream []byte
+	used      int
+}
+
+func newMTStream(seed uint32) cipher.Stream {
+	return &mtStream{
+		mt:        prng.NewMT19937(seed),
+		keystream: make([]byte, 4),
+		used:      4,
+	}
+}
+
+func (s *mtStream) XORKeyStream(dst, src []byte) {
+	if len(dst) < len(src) {
+		panic("MT19937 XORKeyStream: output smaller than input")
+	}
+
+	for i := range src {
+		if s.used == len(s.keystream) {
+			binary.LittleEndian.PutUint32(s.keystream, s.mt.Uint32())
+			s.used = 0
+		}
+		dst[i] = src[i] ^ s.keystream[s.used]
+		s.used++
+	}
+}
+
+// Returns the first n bytes of the keystream generated from a given seed.
+func mtKeystream(seed uint32, n int) []byte {
+	keystream := make([]byte, n)
+	newMTStream(seed).XORKeyStream(keystream, keystream)
+	return keystream
+}
+
+// Encrypts or decrypts a base64-encoded input with the MT19937 stream cipher under a given 16-bit key and returns the
+// result as a base64-encoded string.
+func MTStream(input string, key uint16) (string, error) {
+	rawInput, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return "", err
+	}
+
+	rawOutput := make([]byte, len(rawInput))
+	newMTStream(uint32(key)).XORKeyStream(rawOutput, rawInput)
+	return base64.StdEncoding.EncodeToString(rawOutput), nil
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"cryptopals/prng"
 	"encoding/base64"
 	"math/big"
 )
@@ -93,3 +94,206 @@ func GetAESECBEncryptionOracle(salt string) func([]byte) []byte {
 		return ciphertext
 	}
 }
+
+// Returns an AES-128-ECB encryption oracle that encrypts the profile of a user with a given email address using a fixed
+// random key, along with a function that decrypts and parses such a profile.
+func GetProfileOracles() (func([]byte) []byte, func([]byte) (map[string]string, error)) {
+	key := randBytes(16)
+	encrypt := func(email []byte) []byte {
+		ciphertext, err := aesECBEncrypt([]byte(profileFor(string(email))), key)
+		if err != nil {
+			panic(err)
+		}
+		return ciphertext
+	}
+	decrypt := func(ciphertext []byte) (map[string]string, error) {
+		plaintext, err := aesECBDecrypt(ciphertext, key)
+		if err != nil {
+			return nil, err
+		}
+		return parseKV(string(plaintext))
+	}
+	return encrypt, decrypt
+}
+
+// Returns an AES-128-ECB encryption oracle that prepends a random prefix and appends a given base64-encoded salt to a
+// plaintext and encrypts with a fixed random key. If varyPrefix is true, a new random prefix is chosen on every call;
+// otherwise the prefix is chosen once.
+func GetAESECBEncryptionOracleWithPrefix(salt string, varyPrefix bool) func([]byte) []byte {
+	rawSalt, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		panic(err)
+	}
+
+	key := randBytes(16)
+	prefix := randBytes(randInt(0, 64))
+	return func(plaintext []byte) []byte {
+		if varyPrefix {
+			prefix = randBytes(randInt(0, 64))
+		}
+		saltedPlaintext := append(append(append([]byte{}, prefix...), plaintext...), rawSalt...)
+		ciphertext, err := aesECBEncrypt(saltedPlaintext, key)
+		if err != nil {
+			panic(err)
+		}
+		return ciphertext
+	}
+}
+
+// Returns an AES-128-CBC encryption oracle that quotes and wraps user data between fixed comment strings and encrypts
+// the result with a fixed random key and IV, along with a function that decrypts such a ciphertext and reports whether
+// it contains "admin=true".
+func GetCBCBitflippingOracles() (func([]byte) []byte, func([]byte) bool) {
+	key, iv := randBytes(16), randBytes(16)
+	encrypt := func(userdata []byte) []byte {
+		ciphertext, err := aesCBCEncrypt(wrapUserData(userdata), key, iv)
+		if err != nil {
+			panic(err)
+		}
+		return ciphertext
+	}
+	check := func(ciphertext []byte) bool {
+		plaintext, err := aesCBCDecrypt(ciphertext, key, iv)
+		if err != nil {
+			return false
+		}
+		return isAdmin(plaintext)
+	}
+	return encrypt, check
+}
+
+// Returns an AES-128-CBC encryption oracle which, on each call, encrypts one of ten fixed base64-encoded strings chosen
+// at random using a fixed random key and a fresh random IV, returning the IV and ciphertext. Also returns a padding
+// oracle which decrypts a given IV and ciphertext and reports whether the plaintext has valid PKCS#7 padding.
+func GetCBCPaddingOracles() (func() ([]byte, []byte), func([]byte, []byte) bool) {
+	plaintexts := []string{
+		"MDAwMDAwTm93IHRoYXQgdGhlIHBhcnR5IGlzIGp1bXBpbmc=",
+		"MDAwMDAxV2l0aCB0aGUgYmFzcyBraWNrZWQgaW4gYW5kIHRoZSBWZWdhJ3MgYXJlIHB1bXBpbic=",
+		"MDAwMDAyUXVpY2sgdG8gdGhlIHBvaW50LCB0byB0aGUgcG9pbnQsIG5vIGZha2luZw==",
+		"MDAwMDAzQ29va2luZyBNQydzIGxpa2UgYSBwb3VuZCBvZiBiYWNvbg==",
+		"MDAwMDA0QnVybmluZyAnZW0sIGlmIHlvdSBhaW4ndCBxdWljayBhbmQgbmltYmxl",
+		"MDAwMDA1SSBnbyBjcmF6eSB3aGVuIEkgaGVhciBhIGN5bWJhbA==",
+		"MDAwMDA2QW5kIGEgaGlnaCBoYXQgd2l0aCBhIHNvdXBlZCB1cCB0ZW1wbw==",
+		"MDAwMDA3SSdtIG9uIGEgcm9sbCwgaXQncyB0aW1lIHRvIGdvIHNvbG8=",
+		"MDAwMDA4b2xsaW4nIGluIG15IGZpdmUgcG9pbnQgb2g=",
+		"MDAwMDA5aXRoIG15IHJhZy10b3AgZG93biBzbyBteSBoYWlyIGNhbiBibG93",
+	}
+
+	key := randBytes(16)
+	encrypt := func() ([]byte, []byte) {
+		plaintext, err := base64.StdEncoding.DecodeString(plaintexts[randInt(0, len(plaintexts))])
+		if err != nil {
+			panic(err)
+		}
+
+		iv := randBytes(16)
+		ciphertext, err := aesCBCEncrypt(plaintext, key, iv)
+		if err != nil {
+			panic(err)
+		}
+		return iv, ciphertext
+	}
+	check := func(iv, ciphertext []byte) bool {
+		_, err := aesCBCDecrypt(ciphertext, key, iv)
+		return err == nil
+	}
+	return encrypt, check
+}
+
+// Returns an AES-128-CTR encryption oracle that encrypts a plaintext with a fixed random key and a fixed zero nonce.
+func GetFixedNonceCTREncryptionOracle() func([]byte) []byte {
+	key := randBytes(16)
+	iv := make([]byte, 16)
+	return func(plaintext []byte) []byte {
+		ciphertext, err := aesCTR(plaintext, key, iv, CTRNonceCounterLE)
+		if err != nil {
+			panic(err)
+		}
+		return ciphertext
+	}
+}
+
+// Returns an MT19937 stream cipher encryption oracle that prepends a random number of random bytes to a plaintext and
+// encrypts with a random 16-bit key. Also returns the key.
+func GetMTStreamEncryptionOracle() (func([]byte) []byte, uint16) {
+	key := uint16(randInt(0, 1<<16))
+	return func(plaintext []byte) []byte {
+		prefixedPlaintext := append(randBytes(randInt(5, 21)), plaintext...)
+		ciphertext := make([]byte, len(prefixedPlaintext))
+		newMTStream(uint32(key)).XORKeyStream(ciphertext, prefixedPlaintext)
+		return ciphertext
+	}, key
+}
+
+// Returns a 16-byte password reset token taken from the keystream of an MT19937 seeded with the current Unix time
+// according to clock.
+func GetPasswordResetToken(clock prng.Clock) []byte {
+	return mtKeystream(uint32(clock.Now().Unix()), 16)
+}
+
+// Encrypts a given plaintext with AES-128-CTR using a random key and nonce, and returns the ciphertext along with an
+// edit oracle which replaces the plaintext of a ciphertext starting at a given offset with new text, without revealing
+// the key. The oracle returns an error if the offset is outside the ciphertext.
+func GetCTREditOracle(plaintext []byte) ([]byte, func([]byte, int, []byte) ([]byte, error)) {
+	key := randBytes(16)
+	iv := append(randBytes(8), make([]byte, 8)...)
+	ciphertext, err := aesCTR(plaintext, key, iv, CTRNonceCounterLE)
+	if err != nil {
+		panic(err)
+	}
+
+	return ciphertext, func(ciphertext []byte, offset int, newtext []byte) ([]byte, error) {
+		return ctrEdit(ciphertext, key, iv, CTRNonceCounterLE, offset, newtext)
+	}
+}
+
+// Returns an AES-128-CTR encryption oracle that quotes and wraps user data between fixed comment strings and encrypts
+// the result with a fixed random key and nonce, along with a function that decrypts such a ciphertext and reports
+// whether it contains "admin=true".
+func GetCTRBitflippingOracles() (func([]byte) []byte, func([]byte) bool) {
+	key := randBytes(16)
+	iv := append(randBytes(8), make([]byte, 8)...)
+	encrypt := func(userdata []byte) []byte {
+		ciphertext, err := aesCTR(wrapUserData(userdata), key, iv, CTRNonceCounterLE)
+		if err != nil {
+			panic(err)
+		}
+		return ciphertext
+	}
+	check := func(ciphertext []byte) bool {
+		plaintext, err := aesCTR(ciphertext, key, iv, CTRNonceCounterLE)
+		if err != nil {
+			return false
+		}
+		return isAdmin(plaintext)
+	}
+	return encrypt, check
+}
+
+// Returns an AES-128-CBC encryption oracle that quotes and wraps user data between fixed comment strings and encrypts
+// the result with a fixed random key which is also used as the IV, along with a function that decrypts such a
+// ciphertext and returns a *HighASCIIError if the plaintext is not ASCII. Also returns the base64-encoded key.
+func GetKeyAsIVCBCOracles() (func([]byte) []byte, func([]byte) error, string) {
+	key := randBytes(16)
+	encrypt := func(userdata []byte) []byte {
+		ciphertext, err := aesCBCEncrypt(wrapUserData(userdata), key, key)
+		if err != nil {
+			panic(err)
+		}
+		return ciphertext
+	}
+	check := func(ciphertext []byte) error {
+		plaintext, err := aesCBC(ciphertext, key, key)
+		if err != nil {
+			return err
+		}
+
+		for _, b := range plaintext {
+			if b > 0x7f {
+				return &HighASCIIError{plaintext}
+			}
+		}
+		return nil
+	}
+	return encrypt, check, base64.StdEncoding.EncodeToString(key)
+}
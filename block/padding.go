@@ -1,10 +1,13 @@
 package block
 
-import "encoding/hex"
+import (
+	"encoding/hex"
+	"fmt"
+)
 
 type padder interface {
 	pad([]byte) []byte
-	unpad([]byte) []byte
+	unpad([]byte) ([]byte, error)
 }
 
 type pkcs struct{ blockSize uint8 }
@@ -23,24 +26,24 @@ func (p *pkcs) pad(rawBytes []byte) []byte {
 	return rawBytes
 }
 
-// Undoes the pad operation and panics if this is not possible.
-func (p *pkcs) unpad(rawBytes []byte) []byte {
+// Undoes the pad operation. Returns an error wrapping ErrInvalidPadding if rawBytes is not padded properly.
+func (p *pkcs) unpad(rawBytes []byte) ([]byte, error) {
 	if len(rawBytes) == 0 {
-		panic("PKCS Unpad: zero-length input")
+		return nil, fmt.Errorf("PKCS unpad: zero-length input: %w", ErrInvalidPadding)
 	}
 
 	toRemove := int(rawBytes[len(rawBytes)-1])
-	if toRemove > len(rawBytes) {
-		panic("PKCS Unpad: input is not padded properly")
+	if toRemove == 0 || toRemove > int(p.blockSize) || toRemove > len(rawBytes) {
+		return nil, fmt.Errorf("PKCS unpad: %w", ErrInvalidPadding)
 	}
 
 	for i := len(rawBytes) - 1; i >= len(rawBytes)-toRemove; i-- {
 		if rawBytes[i] != byte(toRemove) {
-			panic("PKCS Unpad: input is not padded properly")
+			return nil, fmt.Errorf("PKCS unpad: %w", ErrInvalidPadding)
 		}
 	}
 
-	return rawBytes[:len(rawBytes)-toRemove]
+	return rawBytes[:len(rawBytes)-toRemove], nil
 }
 
 // Returns hexString padded according to PKCS with a given block size.
@@ -54,3 +57,20 @@ func PKCSPadding(hexString string, blockSize uint8) (string, error) {
 	rawPadded := p.pad(rawBytes)
 	return hex.EncodeToString(rawPadded), nil
 }
+
+// Returns hexString with its PKCS padding for a given block size removed, or an error wrapping ErrInvalidPadding if it
+// is not padded properly.
+func PKCSUnpadding(hexString string, blockSize uint8) (string, error) {
+	rawBytes, err := hex.DecodeString(hexString)
+	if err != nil {
+		return "", err
+	}
+
+	p := newPKCS(blockSize)
+	rawUnpadded, err := p.unpad(rawBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(rawUnpadded), nil
+}
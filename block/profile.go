@@ -0,0 +1,46 @@
+package block
+
+import (
+	"errors"
+	"strings"
+)
+
+// This file provides a structured cookie format of the form "k1=v1&k2=v2", as used by the ECB cut-and-paste challenge.
+
+type kvPair struct{ key, value string }
+
+var (
+	kvEscaper   = strings.NewReplacer("%", "%25", "&", "%26", "=", "%3D")
+	kvUnescaper = strings.NewReplacer("%25", "%", "%26", "&", "%3D", "=")
+)
+
+// Encodes pairs as "k1=v1&k2=v2", in order, escaping any metacharacters in the keys and values.
+func encodeKV(pairs []kvPair) string {
+	fields := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		fields = append(fields, kvEscaper.Replace(pair.key)+"="+kvEscaper.Replace(pair.value))
+	}
+	return strings.Join(fields, "&")
+}
+
+// Parses a string of the form "k1=v1&k2=v2" into a map, undoing the escaping done by encodeKV.
+func parseKV(s string) (map[string]string, error) {
+	m := make(map[string]string)
+	for _, field := range strings.Split(s, "&") {
+		kv := strings.Split(field, "=")
+		if len(kv) != 2 {
+			return nil, errors.New("parseKV: malformed field")
+		}
+		m[kvUnescaper.Replace(kv[0])] = kvUnescaper.Replace(kv[1])
+	}
+	return m, nil
+}
+
+// Returns the encoded profile of an ordinary user with a given email address.
+func profileFor(email string) string {
+	return encodeKV([]kvPair{
+		{"email", email},
+		{"uid", "10"},
+		{"role", "user"},
+	})
+}
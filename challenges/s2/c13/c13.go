@@ -0,0 +1,27 @@
+package main
+
+import (
+	"cryptopals/block"
+	"encoding/base64"
+	"fmt"
+	"log"
+)
+
+func main() {
+	encrypt, decrypt := block.GetProfileOracles()
+	forged := block.ECBCutAndPaste(encrypt)
+	rawForged, err := base64.StdEncoding.DecodeString(forged)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	profile, err := decrypt(rawForged)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if profile["role"] == "admin" {
+		fmt.Println("Successfully forged an admin profile.")
+	}
+	fmt.Printf("Profile: %v\n", profile)
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"cryptopals/block"
+	"encoding/base64"
+	"fmt"
+)
+
+func main() {
+	salt := "Um9sbGluJyBpbiBteSA1LjAKV2l0aCBteSByYWctdG9wIGRvd24gc28gbXkgaGFpciBjYW4gYmxvdwpUaGUgZ2lybGllcyBvbiBzdGFuZGJ5IHdhdmluZyBqdXN0IHRvIHNheSBoaQpEaWQgeW91IHN0b3A/IE5vLCBJIGp1c3QgZHJvdmUgYnkK"
+	for _, varyPrefix := range []bool{false, true} {
+		oracle := block.GetAESECBEncryptionOracleWithPrefix(salt, varyPrefix)
+		found := block.ByteAtATimeECBDecryptionWithPrefix(oracle)
+		if salt == found {
+			fmt.Printf("Successfully found the salt (varying prefix: %v).\n", varyPrefix)
+			rawSalt, _ := base64.StdEncoding.DecodeString(found)
+			fmt.Printf("Salt: %q\n", rawSalt)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"cryptopals/block"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+)
+
+func main() {
+	inputs := []string{
+		"ICE ICE BABY\x04\x04\x04\x04",
+		"ICE ICE BABY\x05\x05\x05\x05",
+		"ICE ICE BABY\x01\x02\x03\x04",
+	}
+	for _, input := range inputs {
+		unpadded, err := block.PKCSUnpadding(hex.EncodeToString([]byte(input)), 16)
+		if errors.Is(err, block.ErrInvalidPadding) {
+			fmt.Printf("%q: invalid padding\n", input)
+			continue
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		rawUnpadded, err := hex.DecodeString(unpadded)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%q: %q\n", input, rawUnpadded)
+	}
+}
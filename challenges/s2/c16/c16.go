@@ -0,0 +1,31 @@
+package main
+
+import (
+	"cryptopals/block"
+	"encoding/base64"
+	"fmt"
+	"log"
+)
+
+func main() {
+	encrypt, isAdmin := block.GetCBCBitflippingOracles()
+	if isAdmin(encrypt([]byte(";admin=true;"))) {
+		log.Fatal("Oracle did not quote the user data.")
+	}
+
+	forged, err := block.CBCBitflipping(encrypt)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rawForged, err := base64.StdEncoding.DecodeString(forged)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if isAdmin(rawForged) {
+		fmt.Println("Successfully injected admin=true.")
+	} else {
+		fmt.Println("Failed to inject admin=true.")
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"cryptopals/block"
+	"encoding/base64"
+	"fmt"
+	"log"
+)
+
+func main() {
+	encrypt, oracle := block.GetCBCPaddingOracles()
+	for i := 0; i < 10; i++ {
+		iv, ciphertext := encrypt()
+		plaintext, queries, err := block.PaddingOracleAttack(oracle, iv, ciphertext)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		rawPlaintext, err := base64.StdEncoding.DecodeString(plaintext)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%q (%d queries)\n", rawPlaintext, queries)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"cryptopals/block"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+)
+
+// Returns the base64 encoding of a hex string, exiting on malformed input.
+func hexToBase64(hexString string) string {
+	b64, err := block.HexToBase64(hexString)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return b64
+}
+
+func main() {
+	// The CTR-AES128 vectors from NIST SP 800-38A, F.5.1 (encryption) and F.5.2 (decryption), which use a single
+	// big-endian counter.
+	key := hexToBase64("2b7e151628aed2a6abf7158809cf4f3c")
+	counter := hexToBase64("f0f1f2f3f4f5f6f7f8f9fafbfcfdfeff")
+	plaintext := "6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e51" +
+		"30c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710"
+	ciphertext := "874d6191b620e3261bef6864990db6ce9806f66b7970fdff8617187bb9fffdff" +
+		"5ae4df3edbd5d35e5b4f09020db03eab1e031dda2fbe03d1792170a0f3009cee"
+	vectors := []struct{ name, input, output string }{
+		{"F.5.1", plaintext, ciphertext},
+		{"F.5.2", ciphertext, plaintext},
+	}
+	for _, v := range vectors {
+		output, err := block.AESCTRString(hexToBase64(v.input), key, counter, block.CTRCounterBE)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		rawOutput, err := base64.StdEncoding.DecodeString(output)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if got := hex.EncodeToString(rawOutput); got != v.output {
+			log.Fatalf("SP 800-38A %s: got %s, want %s", v.name, got, v.output)
+		}
+	}
+	fmt.Println("CTR passes the SP 800-38A F.5.1 and F.5.2 vectors.")
+
+	file, err := os.Open("c18.in")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	key = base64.StdEncoding.EncodeToString([]byte("YELLOW SUBMARINE"))
+	// base64 encoding of a zero nonce followed by a zero counter
+	iv := "AAAAAAAAAAAAAAAAAAAAAA=="
+	decrypted, err := block.AESCTR(file, key, iv, block.CTRNonceCounterLE)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rawPlaintext, err := base64.StdEncoding.DecodeString(decrypted)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%q\n", rawPlaintext)
+}
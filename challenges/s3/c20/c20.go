@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bufio"
+	"cryptopals/block"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	file, err := os.Open("c20.in")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Encrypt each base64-encoded plaintext under the same key and nonce.
+	encrypt := block.GetFixedNonceCTREncryptionOracle()
+	var ciphertexts [][]byte
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		plaintext, err := base64.StdEncoding.DecodeString(scanner.Text())
+		if err != nil {
+			log.Fatal(err)
+		}
+		ciphertexts = append(ciphertexts, encrypt(plaintext))
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	keystream, plaintexts, err := block.BreakFixedNonceCTR(ciphertexts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Keystream: %x\n\nPlaintexts:\n", keystream)
+	for _, plaintext := range plaintexts {
+		fmt.Printf("%q\n", plaintext)
+	}
+}
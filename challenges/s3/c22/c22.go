@@ -0,0 +1,24 @@
+package main
+
+import (
+	"cryptopals/prng"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	// Simulate the waiting rather than actually sleeping for up to half an hour.
+	clock := prng.NewSimulatedClock(time.Now())
+	output, seed := prng.GetTimestampSeededOutput(clock)
+
+	now := clock.Now()
+	recovered, tried, err := prng.RecoverTimestampSeed(output, now.Add(-2000*time.Second), now)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if recovered == seed {
+		fmt.Printf("Successfully recovered the seed %d after trying %d candidates.\n", recovered, tried)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"crypto/rand"
+	"cryptopals/prng"
+	"encoding/binary"
+	"fmt"
+	"log"
+)
+
+func main() {
+	var seed uint32
+	if err := binary.Read(rand.Reader, binary.LittleEndian, &seed); err != nil {
+		log.Fatal(err)
+	}
+
+	mt := prng.NewMT19937(seed)
+	var outputs [624]uint32
+	for i := range outputs {
+		outputs[i] = mt.Uint32()
+	}
+
+	clone := prng.Clone(outputs)
+	for i := 0; i < 1000; i++ {
+		if clone.Uint32() != mt.Uint32() {
+			fmt.Println("Clone did not predict the generator's output.")
+			return
+		}
+	}
+	fmt.Println("Clone successfully predicted the generator's next 1000 outputs.")
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"crypto/rand"
+	"cryptopals/block"
+	"cryptopals/prng"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	oracle, key := block.GetMTStreamEncryptionOracle()
+	recovered, err := block.RecoverMTStreamKey(oracle)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if recovered == key {
+		fmt.Printf("Successfully recovered the key %d.\n", recovered)
+	}
+
+	clock := prng.NewSimulatedClock(time.Now())
+	token := block.GetPasswordResetToken(clock)
+	clock.Sleep(10 * time.Minute)
+	fmt.Printf("Time-seeded token detected: %v\n", block.IsTimeSeededMTToken(token, clock.Now(), time.Hour))
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Random token detected: %v\n", block.IsTimeSeededMTToken(random, clock.Now(), time.Hour))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"cryptopals/block"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	file, err := os.Open("c25.in")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	key := base64.StdEncoding.EncodeToString([]byte("YELLOW SUBMARINE"))
+	plaintext, err := block.AESECB(file, key)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rawPlaintext, err := base64.StdEncoding.DecodeString(plaintext)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ciphertext, edit := block.GetCTREditOracle(rawPlaintext)
+	recovered, err := block.BreakRandomAccessCTR(ciphertext, edit)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if recovered == plaintext {
+		fmt.Println("Successfully recovered the plaintext.")
+	}
+
+	rawRecovered, err := base64.StdEncoding.DecodeString(recovered)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%q\n", rawRecovered)
+}
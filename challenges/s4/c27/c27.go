@@ -0,0 +1,20 @@
+package main
+
+import (
+	"cryptopals/block"
+	"fmt"
+	"log"
+)
+
+func main() {
+	encrypt, check, key := block.GetKeyAsIVCBCOracles()
+	recovered, err := block.RecoverKeyAsIV(encrypt, check)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if recovered == key {
+		fmt.Println("Successfully recovered the key.")
+	}
+	fmt.Printf("Key: %s\n", recovered)
+}
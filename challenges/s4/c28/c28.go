@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"cryptopals/mac"
+	"fmt"
+	"log"
+)
+
+func main() {
+	// Check the implementation against crypto/sha1 on random inputs of every length up to a few blocks.
+	for n := 0; n < 256; n++ {
+		input := make([]byte, n)
+		if _, err := rand.Read(input); err != nil {
+			log.Fatal(err)
+		}
+
+		h := mac.NewSHA1()
+		h.Write(input)
+		want := sha1.Sum(input)
+		if !bytes.Equal(h.Sum(nil), want[:]) {
+			log.Fatalf("SHA-1 mismatch for input %x", input)
+		}
+	}
+	fmt.Println("SHA-1 matches crypto/sha1.")
+
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		log.Fatal(err)
+	}
+
+	message := []byte("comment1=cooking%20MCs;userdata=foo;comment2=%20like%20a%20pound%20of%20bacon")
+	tag := mac.SecretPrefixSHA1(key, message)
+	fmt.Printf("MAC verifies: %v\n", mac.VerifySecretPrefixSHA1(key, message, tag))
+
+	tampered := append([]byte{}, message...)
+	tampered[len(tampered)-1] ^= 1
+	fmt.Printf("MAC verifies for tampered message: %v\n", mac.VerifySecretPrefixSHA1(key, tampered, tag))
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"cryptopals/mac"
+	"fmt"
+	"log"
+)
+
+func main() {
+	message := []byte("comment1=cooking%20MCs;userdata=foo;comment2=%20like%20a%20pound%20of%20bacon")
+	extension := []byte(";admin=true")
+	constructions := []struct {
+		name string
+		c    *mac.Construction
+	}{
+		{"SHA-1", mac.SHA1Construction},
+		{"SHA-256", mac.SHA256Construction},
+		{"MD4", mac.MD4Construction},
+	}
+	for _, construction := range constructions {
+		tag, verify := mac.GetSecretPrefixMACOracle(construction.c, message)
+		forged, forgedTag, keyLen, err := mac.LengthExtension(construction.c, message, tag, extension, 0, 64, verify)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("%s: forged a MAC for %q (key length %d).\n", construction.name, forged, keyLen)
+		fmt.Printf("MAC: %x\n\n", forgedTag)
+	}
+}
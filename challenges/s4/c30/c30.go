@@ -0,0 +1,41 @@
+package main
+
+import (
+	"cryptopals/mac"
+	"encoding/hex"
+	"fmt"
+	"log"
+)
+
+func main() {
+	// The test suite from RFC 1320, appendix A.5.
+	suite := []struct{ input, digest string }{
+		{"", "31d6cfe0d16ae931b73c59d7e0c089c0"},
+		{"a", "bde52cb31de33e46245e05fbdbd6fb24"},
+		{"abc", "a448017aaf21d8525fc10ae87aa6729d"},
+		{"message digest", "d9130a8164549fe818874806e1c7014b"},
+		{"abcdefghijklmnopqrstuvwxyz", "d79e1c308aa5bbcdeea8ed63df412da9"},
+		{"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789", "043f8582f241db351ce627e153e7f0e4"},
+		{"12345678901234567890123456789012345678901234567890123456789012345678901234567890",
+			"e33b4ddc9c38f2199c3e7b164fcc0536"},
+	}
+	for _, test := range suite {
+		h := mac.NewMD4()
+		h.Write([]byte(test.input))
+		if digest := hex.EncodeToString(h.Sum(nil)); digest != test.digest {
+			log.Fatalf("MD4(%q) = %s, want %s", test.input, digest, test.digest)
+		}
+	}
+	fmt.Println("MD4 passes the RFC 1320 test suite.")
+
+	message := []byte("comment1=cooking%20MCs;userdata=foo;comment2=%20like%20a%20pound%20of%20bacon")
+	tag, verify := mac.GetSecretPrefixMACOracle(mac.MD4Construction, message)
+	forged, forgedTag, keyLen, err := mac.LengthExtension(mac.MD4Construction, message, tag, []byte(";admin=true"), 0,
+		64, verify)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Forged a MAC for %q (key length %d).\n", forged, keyLen)
+	fmt.Printf("MAC: %x\n", forgedTag)
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"cryptopals/mac"
+	"cryptopals/timing"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	server := mac.GetTimingLeakServer(50 * time.Millisecond)
+	defer server.Close()
+
+	// A 50ms leak is large enough to see from a single request per candidate. This still takes a while: each byte needs
+	// 256 requests, each of which sleeps 50ms per correct byte.
+	signature, err := mac.TimingAttack(server.URL, "foo", &timing.Engine{Samples: 1})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Found a valid signature for foo: %x\n", signature)
+}
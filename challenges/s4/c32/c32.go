@@ -0,0 +1,23 @@
+package main
+
+import (
+	"cryptopals/mac"
+	"cryptopals/timing"
+	"fmt"
+	"log"
+	"time"
+)
+
+func main() {
+	server := mac.GetTimingLeakServer(5 * time.Millisecond)
+	defer server.Close()
+
+	// A 5ms leak is close to the noise in request times, so sample each candidate repeatedly and re-sample the leaders
+	// until the slowest one stands out.
+	signature, err := mac.TimingAttack(server.URL, "foo", timing.NewEngine())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Found a valid signature for foo: %x\n", signature)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"cryptopals/block"
+	"cryptopals/pubkey/dh"
+	"encoding/base64"
+	"fmt"
+	"log"
+)
+
+func main() {
+	groups := []struct {
+		name  string
+		group *dh.Group
+	}{
+		{"Toy group (p = 37, g = 5)", dh.ToyGroup()},
+		{"1536-bit MODP group", dh.MODP1536()},
+	}
+	for _, g := range groups {
+		alice, err := dh.GenerateKey(g.group)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		bob, err := dh.GenerateKey(g.group)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		s1, s2 := alice.SharedSecret(bob.Public), bob.SharedSecret(alice.Public)
+		fmt.Printf("%s: shared secrets agree: %v\n", g.name, s1.Cmp(s2) == 0)
+
+		// Use the session key with AES-CBC to check that both sides can talk.
+		key := base64.StdEncoding.EncodeToString(dh.SessionKey(s1))
+		iv := "AAAAAAAAAAAAAAAAAAAAAA=="
+		message := base64.StdEncoding.EncodeToString([]byte("Hello, Bob"))
+		ciphertext, err := block.EncryptAESCBC(message, key, iv)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		plaintext, err := block.DecryptAESCBC(ciphertext, base64.StdEncoding.EncodeToString(dh.SessionKey(s2)), iv)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		rawPlaintext, err := base64.StdEncoding.DecodeString(plaintext)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Bob decrypted: %q\n", rawPlaintext)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"cryptopals/protocol"
+	"cryptopals/pubkey/dh"
+	"fmt"
+	"log"
+)
+
+func main() {
+	messages := [][]byte{
+		[]byte("Hi Bob, it's Alice."),
+		[]byte("Meet me at the usual place."),
+	}
+
+	network := protocol.NewNetwork(nil, protocol.Alice, protocol.Bob)
+	echoed, err := protocol.RunDHEcho(network, dh.MODP1536(), messages)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Without Mallory, Bob echoed %d messages.\n", len(echoed))
+
+	mallory := protocol.NewParameterInjection()
+	network = protocol.NewNetwork(mallory.Intercept, protocol.Alice, protocol.Bob)
+	echoed, err = protocol.RunDHEcho(network, dh.MODP1536(), messages)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("With Mallory, Bob still echoed %d messages, and Mallory read:\n", len(echoed))
+	for _, m := range mallory.Recovered() {
+		fmt.Printf("%q\n", m)
+	}
+	fmt.Printf("The transcript has %d entries.\n", len(network.Transcript.Entries()))
+}
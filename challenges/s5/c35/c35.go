@@ -0,0 +1,34 @@
+package main
+
+import (
+	"cryptopals/protocol"
+	"cryptopals/pubkey/dh"
+	"fmt"
+	"log"
+)
+
+func main() {
+	messages := [][]byte{
+		[]byte("Hi Bob, it's Alice."),
+		[]byte("Meet me at the usual place."),
+	}
+
+	variants := []protocol.MaliciousGenerator{
+		protocol.GeneratorOne,
+		protocol.GeneratorP,
+		protocol.GeneratorPMinusOne,
+	}
+	for _, variant := range variants {
+		mallory := protocol.NewNegotiatedGroupMITM(variant)
+		network := protocol.NewNetwork(mallory.Intercept, protocol.Alice, protocol.Bob)
+		if _, err := protocol.RunNegotiatedDHEcho(network, dh.MODP1536(), messages); err != nil {
+			log.Fatal(err)
+		}
+
+		recovered := mallory.Recovered()
+		fmt.Printf("With %v, Mallory read %d of %d messages:\n", mallory.Variant(), len(recovered), 2*len(messages))
+		for _, m := range recovered {
+			fmt.Printf("%q\n", m)
+		}
+	}
+}
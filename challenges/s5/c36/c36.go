@@ -0,0 +1,40 @@
+package main
+
+import (
+	"cryptopals/pubkey/srp"
+	"encoding/hex"
+	"fmt"
+	"log"
+)
+
+func main() {
+	params := srp.DefaultParams()
+	server := srp.NewServer(params)
+	if err := server.Register("alice@example.com", []byte("correct horse battery staple")); err != nil {
+		log.Fatal(err)
+	}
+
+	httpServer := srp.NewHTTPServer(server)
+	defer httpServer.Close()
+
+	authenticators := []struct {
+		name string
+		auth srp.Authenticator
+	}{
+		{"in-process", server},
+		{"HTTP", &srp.HTTPAuthenticator{URL: httpServer.URL}},
+	}
+	for _, a := range authenticators {
+		key, err := srp.Login(a.auth, params, "alice@example.com", []byte("correct horse battery staple"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Logged in %s with session key %s\n", a.name, hex.EncodeToString(key))
+
+		_, err = srp.Login(a.auth, params, "alice@example.com", []byte("Tr0ub4dor&3"))
+		fmt.Printf("Logging in %s with the wrong password fails: %v\n", a.name, err)
+
+		_, err = srp.Login(a.auth, params, "mallory@example.com", []byte("correct horse battery staple"))
+		fmt.Printf("Logging in %s as an unknown user fails: %v\n", a.name, err)
+	}
+}
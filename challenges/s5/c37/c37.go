@@ -0,0 +1,46 @@
+package main
+
+import (
+	"cryptopals/pubkey/srp"
+	"fmt"
+	"log"
+)
+
+func main() {
+	params := srp.DefaultParams()
+	multiples := []int64{0, 1, 2, 3}
+
+	modes := []struct {
+		name string
+		mode srp.Mode
+	}{
+		{"vulnerable", srp.Vulnerable},
+		{"hardened", srp.Hardened},
+	}
+	for _, m := range modes {
+		server := srp.NewServerWithMode(params, m.mode)
+		if err := server.Register("alice@example.com", []byte("correct horse battery staple")); err != nil {
+			log.Fatal(err)
+		}
+
+		httpServer := srp.NewHTTPServer(server)
+		authenticators := []struct {
+			name string
+			auth srp.Authenticator
+		}{
+			{"in-process", server},
+			{"HTTP", &srp.HTTPAuthenticator{URL: httpServer.URL}},
+		}
+		for _, a := range authenticators {
+			fmt.Printf("Against the %s server (%s):\n", m.name, a.name)
+			for _, r := range srp.ZeroKeyBypass(a.auth, params, "alice@example.com", multiples) {
+				if r.Err != nil {
+					fmt.Printf("  A = %dN was rejected: %v\n", r.Multiple, r.Err)
+				} else {
+					fmt.Printf("  A = %dN logged in without the password\n", r.Multiple)
+				}
+			}
+		}
+		httpServer.Close()
+	}
+}
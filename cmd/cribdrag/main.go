@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"cryptopals/block"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const usage = `Commands:
+  show                          print the current plaintexts
+  drag <i> <j> <crib>           slide crib across plaintext i and show what it reveals in plaintext j
+  commit <i> <offset> <text>    assume plaintext i contains text at offset and update the keystream
+  keystream                     print the current keystream
+  help                          print this message
+  quit                          exit`
+
+// The number of matches printed by the drag command.
+const maxMatches = 10
+
+func show(d *block.CribDragger) {
+	for i, plaintext := range d.Plaintexts() {
+		fmt.Printf("%3d: %q\n", i, plaintext)
+	}
+}
+
+func drag(d *block.CribDragger, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: drag <i> <j> <crib>")
+	}
+
+	i, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	j, err := strconv.Atoi(args[1])
+	if err != nil {
+		return err
+	}
+
+	matches, err := d.Drag(i, j, []byte(args[2]))
+	if err != nil {
+		return err
+	}
+
+	for k, match := range matches {
+		if k == maxMatches {
+			break
+		}
+		fmt.Printf("offset %3d, score %3d: %q\n", match.Offset, match.Score, match.Revealed)
+	}
+	return nil
+}
+
+func commit(d *block.CribDragger, args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: commit <i> <offset> <text>")
+	}
+
+	i, err := strconv.Atoi(args[0])
+	if err != nil {
+		return err
+	}
+
+	offset, err := strconv.Atoi(args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := d.Commit(i, offset, []byte(args[2])); err != nil {
+		return err
+	}
+
+	fmt.Printf("%3d: %q\n", i, d.Plaintexts()[i])
+	return nil
+}
+
+// Returns a CribDragger for a file of base64-encoded plaintexts, one per line, after encrypting them all under a random
+// key and a fixed nonce.
+func encryptFile(file *os.File) (*block.CribDragger, error) {
+	encrypt := block.GetFixedNonceCTREncryptionOracle()
+	var ciphertexts [][]byte
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		plaintext, err := base64.StdEncoding.DecodeString(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		ciphertexts = append(ciphertexts, encrypt(plaintext))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	keystream, _, err := block.BreakFixedNonceCTR(ciphertexts)
+	if err != nil {
+		return nil, err
+	}
+	return block.NewCribDragger(ciphertexts, keystream), nil
+}
+
+func main() {
+	encrypt := flag.Bool("encrypt", false, "treat the file as base64-encoded plaintexts and encrypt them first")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [-encrypt] <file of base64-encoded messages, one per line>\n",
+			os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var d *block.CribDragger
+	if *encrypt {
+		d, err = encryptFile(file)
+	} else {
+		d, err = block.NewCribDraggerFromFile(file)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	show(d)
+	scanner := bufio.NewScanner(os.Stdin)
+	for fmt.Print("> "); scanner.Scan(); fmt.Print("> ") {
+		// The last argument may contain spaces, so only split off the command and the numeric arguments.
+		fields := strings.SplitN(scanner.Text(), " ", 4)
+		switch fields[0] {
+		case "show":
+			show(d)
+		case "drag":
+			err = drag(d, fields[1:])
+		case "commit":
+			err = commit(d, fields[1:])
+		case "keystream":
+			fmt.Printf("%x\n", d.Keystream())
+		case "help":
+			fmt.Println(usage)
+		case "quit":
+			return
+		case "":
+		default:
+			err = fmt.Errorf("unknown command %q; try help", fields[0])
+		}
+		if err != nil {
+			fmt.Println(err)
+			err = nil
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package mac
+
+import (
+	"cryptopals/timing"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Requests the signature check for a given file and signature from a timing leak server, returning whether the
+// signature was accepted and how long the request took.
+func timeSignatureCheck(baseURL, file string, signature []byte) (bool, time.Duration, error) {
+	query := url.Values{}
+	query.Set("file", file)
+	query.Set("signature", hex.EncodeToString(signature))
+
+	start := time.Now()
+	resp, err := http.Get(baseURL + "/test?" + query.Encode())
+	elapsed := time.Since(start)
+	if err != nil {
+		return false, elapsed, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, elapsed, nil
+}
+
+// Given the base URL of a server which checks HMAC-SHA1 signatures with an early-exit comparison, returns a valid
+// signature for file, found one byte at a time by using engine to pick the candidate whose check takes the longest.
+func TimingAttack(baseURL, file string, engine *timing.Engine) ([]byte, error) {
+	signature := make([]byte, sha1Size)
+	for i := range signature {
+		candidates := make([][]byte, 256)
+		for b := range candidates {
+			candidates[b] = append([]byte{}, signature...)
+			candidates[b][i] = byte(b)
+		}
+
+		var probeErr error
+		probe := func(candidate []byte) time.Duration {
+			_, elapsed, err := timeSignatureCheck(baseURL, file, candidate)
+			if err != nil && probeErr == nil {
+				probeErr = err
+			}
+			return elapsed
+		}
+
+		result, err := engine.Slowest(candidates, probe)
+		if err != nil {
+			return nil, err
+		}
+
+		if probeErr != nil {
+			return nil, probeErr
+		}
+		signature[i] = byte(result.Index)
+	}
+
+	ok, _, err := timeSignatureCheck(baseURL, file, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, errors.New("TimingAttack: recovered signature was not accepted")
+	}
+	return signature, nil
+}
@@ -0,0 +1,14 @@
+package mac
+
+import "encoding/binary"
+
+// Returns the Merkle-Damgård padding for a message of a given length (in bytes): a 1 bit, then zeros up to 8 bytes
+// short of a block boundary, then the message length in bits, encoded according to order.
+func mdPadding(length uint64, blockSize int, order binary.ByteOrder) []byte {
+	padLen := 9 + (blockSize-int((length+9)%uint64(blockSize)))%blockSize
+
+	padding := make([]byte, padLen)
+	padding[0] = 0x80
+	order.PutUint64(padding[padLen-8:], length*8)
+	return padding
+}
@@ -0,0 +1,73 @@
+package mac
+
+import (
+	"crypto/subtle"
+	"hash"
+)
+
+// This file provides a "from scratch" implementation of HMAC (RFC 2104) over any hash.Hash.
+
+type hmacDigest struct {
+	inner, outer hash.Hash
+	ipad, opad   []byte
+}
+
+// Returns a new HMAC hash using a given hash function and key.
+func NewHMAC(newHash func() hash.Hash, key []byte) hash.Hash {
+	inner, outer := newHash(), newHash()
+	blockSize := inner.BlockSize()
+	if len(key) > blockSize {
+		inner.Write(key)
+		key = inner.Sum(nil)
+		inner.Reset()
+	}
+
+	d := &hmacDigest{
+		inner: inner,
+		outer: outer,
+		ipad:  make([]byte, blockSize),
+		opad:  make([]byte, blockSize),
+	}
+	copy(d.ipad, key)
+	copy(d.opad, key)
+	for i := range d.ipad {
+		d.ipad[i] ^= 0x36
+		d.opad[i] ^= 0x5c
+	}
+	d.Reset()
+	return d
+}
+
+func (d *hmacDigest) Reset() {
+	d.inner.Reset()
+	d.inner.Write(d.ipad)
+}
+
+func (d *hmacDigest) Size() int { return d.outer.Size() }
+
+func (d *hmacDigest) BlockSize() int { return d.inner.BlockSize() }
+
+func (d *hmacDigest) Write(p []byte) (int, error) {
+	return d.inner.Write(p)
+}
+
+// Appends the HMAC of the data written so far to in, without changing the underlying state.
+func (d *hmacDigest) Sum(in []byte) []byte {
+	innerSum := d.inner.Sum(nil)
+	d.outer.Reset()
+	d.outer.Write(d.opad)
+	d.outer.Write(innerSum)
+	return d.outer.Sum(in)
+}
+
+// Returns HMAC-SHA1(key, message).
+func HMACSHA1(key, message []byte) []byte {
+	h := NewHMAC(NewSHA1, key)
+	h.Write(message)
+	return h.Sum(nil)
+}
+
+// Returns true iff mac is HMAC-SHA1(key, message).
+func VerifyHMACSHA1(key, message, mac []byte) bool {
+	return subtle.ConstantTimeCompare(HMACSHA1(key, message), mac) == 1
+}
@@ -0,0 +1,115 @@
+package mac
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+)
+
+// This file provides a length-extension attack which works against any Merkle-Damgård hash whose digest is its final
+// chaining state, such as SHA-1, SHA-256 and MD4.
+
+// A Construction describes a Merkle-Damgård hash function in enough detail to extend its digests.
+type Construction struct {
+	blockSize int
+	// The byte order of the length field in the padding and of the state words in the digest.
+	order        binary.ByteOrder
+	stateWords   int
+	new          func() hash.Hash
+	newFromState func(state []uint32, length uint64) hash.Hash
+}
+
+// SHA1Construction describes SHA-1.
+var SHA1Construction = &Construction{
+	blockSize:  sha1BlockSize,
+	order:      binary.BigEndian,
+	stateWords: 5,
+	new:        NewSHA1,
+	newFromState: func(state []uint32, length uint64) hash.Hash {
+		var h [5]uint32
+		copy(h[:], state)
+		return NewSHA1FromState(h, length)
+	},
+}
+
+// SHA256Construction describes SHA-256.
+var SHA256Construction = &Construction{
+	blockSize:  sha256BlockSize,
+	order:      binary.BigEndian,
+	stateWords: 8,
+	new:        NewSHA256,
+	newFromState: func(state []uint32, length uint64) hash.Hash {
+		var h [8]uint32
+		copy(h[:], state)
+		return NewSHA256FromState(h, length)
+	},
+}
+
+// MD4Construction describes MD4.
+var MD4Construction = &Construction{
+	blockSize:  md4BlockSize,
+	order:      binary.LittleEndian,
+	stateWords: 4,
+	new:        NewMD4,
+	newFromState: func(state []uint32, length uint64) hash.Hash {
+		var h [4]uint32
+		copy(h[:], state)
+		return NewMD4FromState(h, length)
+	},
+}
+
+// Returns a new hash of this construction.
+func (c *Construction) New() hash.Hash {
+	return c.new()
+}
+
+// Returns the chaining state encoded in a digest.
+func (c *Construction) state(digest []byte) ([]uint32, error) {
+	if len(digest) != 4*c.stateWords {
+		return nil, errors.New("Construction: digest length does not match the state size")
+	}
+
+	state := make([]uint32, c.stateWords)
+	for i := range state {
+		state[i] = c.order.Uint32(digest[4*i:])
+	}
+	return state, nil
+}
+
+// Returns the padding which the hash appends to a message of a given length (in bytes).
+func (c *Construction) padding(length uint64) []byte {
+	return mdPadding(length, c.blockSize, c.order)
+}
+
+// Given the secret-prefix MAC tag of message under a key of length keyLen, returns message || glue padding ||
+// extension and its MAC under the same key, without knowing the key.
+func (c *Construction) extend(message, tag, extension []byte, keyLen int) ([]byte, []byte, error) {
+	state, err := c.state(tag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	glue := c.padding(uint64(keyLen + len(message)))
+	forged := append(append(append([]byte{}, message...), glue...), extension...)
+	h := c.newFromState(state, uint64(keyLen+len(message)+len(glue)))
+	h.Write(extension)
+	return forged, h.Sum(nil), nil
+}
+
+// Given the secret-prefix MAC tag of message under a key whose length lies between minKeyLen and maxKeyLen (inclusive),
+// returns a message which ends with extension along with a valid MAC for it, by trying each key length against verify.
+// Also returns the key length.
+func LengthExtension(c *Construction, message, tag, extension []byte, minKeyLen, maxKeyLen int,
+	verify func([]byte, []byte) bool) ([]byte, []byte, int, error) {
+	for keyLen := minKeyLen; keyLen <= maxKeyLen; keyLen++ {
+		forged, forgedTag, err := c.extend(message, tag, extension, keyLen)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+
+		if verify(forged, forgedTag) {
+			return forged, forgedTag, keyLen, nil
+		}
+	}
+	return nil, nil, 0, errors.New("LengthExtension: no key length in the range produced a valid MAC")
+}
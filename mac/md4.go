@@ -0,0 +1,125 @@
+package mac
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// This file provides a "from scratch" implementation of MD4 (RFC 1320) which satisfies Go's hash.Hash interface. As
+// with sha1.go, it allows the initial chaining registers and message length to be set by the caller.
+
+const (
+	md4Size      = 16
+	md4BlockSize = 64
+)
+
+var md4Init = [4]uint32{0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476}
+
+// The order in which message words are used, and the rotation amounts, in each of the three rounds.
+var (
+	md4Round2Order = [16]int{0, 4, 8, 12, 1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15}
+	md4Round3Order = [16]int{0, 8, 4, 12, 2, 10, 6, 14, 1, 9, 5, 13, 3, 11, 7, 15}
+	md4Shifts      = [3][4]int{{3, 7, 11, 19}, {3, 5, 9, 13}, {3, 9, 11, 15}}
+)
+
+type md4Digest struct {
+	h       [4]uint32
+	x       [md4BlockSize]byte
+	nx      int
+	len     uint64
+	initH   [4]uint32
+	initLen uint64
+}
+
+// Returns a new MD4 hash.
+func NewMD4() hash.Hash {
+	return NewMD4FromState(md4Init, 0)
+}
+
+// Returns a new MD4 hash whose chaining registers are set to h, as if length bytes had already been processed. length
+// must be a multiple of the block size.
+func NewMD4FromState(h [4]uint32, length uint64) hash.Hash {
+	if length%md4BlockSize != 0 {
+		panic("NewMD4FromState: length is not a multiple of the block size")
+	}
+
+	d := &md4Digest{initH: h, initLen: length}
+	d.Reset()
+	return d
+}
+
+func (d *md4Digest) Reset() {
+	d.h = d.initH
+	d.nx = 0
+	d.len = d.initLen
+}
+
+func (d *md4Digest) Size() int { return md4Size }
+
+func (d *md4Digest) BlockSize() int { return md4BlockSize }
+
+func (d *md4Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+	if d.nx > 0 {
+		copied := copy(d.x[d.nx:], p)
+		d.nx += copied
+		p = p[copied:]
+		if d.nx == md4BlockSize {
+			d.block(d.x[:])
+			d.nx = 0
+		}
+	}
+
+	for len(p) >= md4BlockSize {
+		d.block(p[:md4BlockSize])
+		p = p[md4BlockSize:]
+	}
+
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+	return n, nil
+}
+
+// Appends the hash of the data written so far to in, without changing the underlying state.
+func (d *md4Digest) Sum(in []byte) []byte {
+	c := *d
+	c.Write(mdPadding(c.len, md4BlockSize, binary.LittleEndian))
+
+	var digest [md4Size]byte
+	for i, h := range c.h {
+		binary.LittleEndian.PutUint32(digest[4*i:], h)
+	}
+	return append(in, digest[:]...)
+}
+
+// Processes a single 64-byte block.
+func (d *md4Digest) block(p []byte) {
+	var x [16]uint32
+	for i := range x {
+		x[i] = binary.LittleEndian.Uint32(p[4*i:])
+	}
+
+	a, b, c, dd := d.h[0], d.h[1], d.h[2], d.h[3]
+	// Each step updates one register and then rotates the roles of the registers.
+	step := func(f uint32, k int, s int) {
+		a, b, c, dd = dd, bits.RotateLeft32(a+f+x[k], s), b, c
+	}
+
+	for i := 0; i < 16; i++ {
+		step((b&c)|(^b&dd), i, md4Shifts[0][i%4])
+	}
+	for i := 0; i < 16; i++ {
+		step(((b&c)|(b&dd)|(c&dd))+0x5a827999, md4Round2Order[i], md4Shifts[1][i%4])
+	}
+	for i := 0; i < 16; i++ {
+		step((b^c^dd)+0x6ed9eba1, md4Round3Order[i], md4Shifts[2][i%4])
+	}
+
+	d.h[0] += a
+	d.h[1] += b
+	d.h[2] += c
+	d.h[3] += dd
+}
@@ -0,0 +1,49 @@
+package mac
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"math/big"
+	"net/http/httptest"
+	"time"
+)
+
+// Generates a random int in the range [lo, hi) using crypto.rand.
+func randInt(lo, hi int) int {
+	if lo > hi {
+		panic("randInt: lo > hi")
+	}
+
+	bigRand, err := rand.Int(rand.Reader, big.NewInt(int64(hi-lo)))
+	if err != nil {
+		panic(err)
+	}
+
+	return int(bigRand.Int64()) + lo
+}
+
+// Returns n random bytes.
+func randBytes(n int) []byte {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Returns the secret-prefix MAC of a given message, using a given hash construction and a random key of random length
+// (between 1 and 64 bytes), along with an oracle which verifies a (message, MAC) pair under the same key.
+func GetSecretPrefixMACOracle(c *Construction, message []byte) ([]byte, func([]byte, []byte) bool) {
+	key := randBytes(randInt(1, 65))
+	tag := secretPrefixMAC(c.New, key, message)
+	return tag, func(message, tag []byte) bool {
+		return subtle.ConstantTimeCompare(secretPrefixMAC(c.New, key, message), tag) == 1
+	}
+}
+
+// Returns a local test server running NewTimingLeakHandler with a random key and a given per-byte delay. The caller
+// should Close the server when done.
+func GetTimingLeakServer(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(NewTimingLeakHandler(randBytes(16), delay))
+}
@@ -0,0 +1,24 @@
+package mac
+
+import (
+	"crypto/subtle"
+	"hash"
+)
+
+// Returns the secret-prefix MAC H(key || message) for a given hash function.
+func secretPrefixMAC(newHash func() hash.Hash, key, message []byte) []byte {
+	h := newHash()
+	h.Write(key)
+	h.Write(message)
+	return h.Sum(nil)
+}
+
+// Returns the secret-prefix MAC SHA1(key || message).
+func SecretPrefixSHA1(key, message []byte) []byte {
+	return secretPrefixMAC(NewSHA1, key, message)
+}
+
+// Returns true iff mac is the secret-prefix MAC SHA1(key || message).
+func VerifySecretPrefixSHA1(key, message, mac []byte) bool {
+	return subtle.ConstantTimeCompare(SecretPrefixSHA1(key, message), mac) == 1
+}
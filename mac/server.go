@@ -0,0 +1,44 @@
+package mac
+
+import (
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// This file provides an HTTP server which checks HMAC-SHA1 signatures of file names with a comparison that leaks timing
+// information, for the timing attack challenges.
+
+// Compares a and b one byte at a time, sleeping for delay after each matching byte and returning as soon as a byte
+// differs.
+func insecureCompare(a, b []byte, delay time.Duration) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return false
+		}
+		time.Sleep(delay)
+	}
+	return len(a) == len(b)
+}
+
+// Returns a handler for requests of the form /test?file=...&signature=..., which responds with 200 OK if signature is
+// the hex-encoded HMAC-SHA1 of file under key and with 500 Internal Server Error otherwise. The signature is checked by
+// insecureCompare with a given per-byte delay.
+func NewTimingLeakHandler(key []byte, delay time.Duration) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		signature, err := hex.DecodeString(query.Get("signature"))
+		if err != nil {
+			http.Error(w, "malformed signature", http.StatusBadRequest)
+			return
+		}
+
+		if !insecureCompare(HMACSHA1(key, []byte(query.Get("file"))), signature, delay) {
+			http.Error(w, "invalid signature", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
@@ -0,0 +1,123 @@
+package mac
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// This file provides a "from scratch" implementation of SHA-1 which satisfies Go's hash.Hash interface. Unlike
+// crypto/sha1, it allows the initial chaining registers and message length to be set by the caller.
+
+const (
+	sha1Size      = 20
+	sha1BlockSize = 64
+)
+
+var sha1Init = [5]uint32{0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476, 0xc3d2e1f0}
+
+type sha1Digest struct {
+	h       [5]uint32
+	x       [sha1BlockSize]byte
+	nx      int
+	len     uint64
+	initH   [5]uint32
+	initLen uint64
+}
+
+// Returns a new SHA-1 hash.
+func NewSHA1() hash.Hash {
+	return NewSHA1FromState(sha1Init, 0)
+}
+
+// Returns a new SHA-1 hash whose chaining registers are set to h, as if length bytes had already been processed.
+// length must be a multiple of the block size.
+func NewSHA1FromState(h [5]uint32, length uint64) hash.Hash {
+	if length%sha1BlockSize != 0 {
+		panic("NewSHA1FromState: length is not a multiple of the block size")
+	}
+
+	d := &sha1Digest{initH: h, initLen: length}
+	d.Reset()
+	return d
+}
+
+func (d *sha1Digest) Reset() {
+	d.h = d.initH
+	d.nx = 0
+	d.len = d.initLen
+}
+
+func (d *sha1Digest) Size() int { return sha1Size }
+
+func (d *sha1Digest) BlockSize() int { return sha1BlockSize }
+
+func (d *sha1Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+	if d.nx > 0 {
+		copied := copy(d.x[d.nx:], p)
+		d.nx += copied
+		p = p[copied:]
+		if d.nx == sha1BlockSize {
+			d.block(d.x[:])
+			d.nx = 0
+		}
+	}
+
+	for len(p) >= sha1BlockSize {
+		d.block(p[:sha1BlockSize])
+		p = p[sha1BlockSize:]
+	}
+
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+	return n, nil
+}
+
+// Appends the hash of the data written so far to in, without changing the underlying state.
+func (d *sha1Digest) Sum(in []byte) []byte {
+	c := *d
+	c.Write(mdPadding(c.len, sha1BlockSize, binary.BigEndian))
+
+	var digest [sha1Size]byte
+	for i, h := range c.h {
+		binary.BigEndian.PutUint32(digest[4*i:], h)
+	}
+	return append(in, digest[:]...)
+}
+
+// Processes a single 64-byte block.
+func (d *sha1Digest) block(p []byte) {
+	var w [80]uint32
+	for i := 0; i < 16; i++ {
+		w[i] = binary.BigEndian.Uint32(p[4*i:])
+	}
+	for i := 16; i < 80; i++ {
+		w[i] = bits.RotateLeft32(w[i-3]^w[i-8]^w[i-14]^w[i-16], 1)
+	}
+
+	a, b, c, dd, e := d.h[0], d.h[1], d.h[2], d.h[3], d.h[4]
+	for i := 0; i < 80; i++ {
+		var f, k uint32
+		switch {
+		case i < 20:
+			f, k = (b&c)|(^b&dd), 0x5a827999
+		case i < 40:
+			f, k = b^c^dd, 0x6ed9eba1
+		case i < 60:
+			f, k = (b&c)|(b&dd)|(c&dd), 0x8f1bbcdc
+		default:
+			f, k = b^c^dd, 0xca62c1d6
+		}
+		t := bits.RotateLeft32(a, 5) + f + e + k + w[i]
+		a, b, c, dd, e = t, a, bits.RotateLeft32(b, 30), c, dd
+	}
+
+	d.h[0] += a
+	d.h[1] += b
+	d.h[2] += c
+	d.h[3] += dd
+	d.h[4] += e
+}
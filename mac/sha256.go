@@ -0,0 +1,135 @@
+package mac
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// This file provides a "from scratch" implementation of SHA-256 which satisfies Go's hash.Hash interface. As with
+// sha1.go, it allows the initial chaining registers and message length to be set by the caller.
+
+const (
+	sha256Size      = 32
+	sha256BlockSize = 64
+)
+
+var sha256Init = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+var sha256K = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+type sha256Digest struct {
+	h       [8]uint32
+	x       [sha256BlockSize]byte
+	nx      int
+	len     uint64
+	initH   [8]uint32
+	initLen uint64
+}
+
+// Returns a new SHA-256 hash.
+func NewSHA256() hash.Hash {
+	return NewSHA256FromState(sha256Init, 0)
+}
+
+// Returns a new SHA-256 hash whose chaining registers are set to h, as if length bytes had already been processed.
+// length must be a multiple of the block size.
+func NewSHA256FromState(h [8]uint32, length uint64) hash.Hash {
+	if length%sha256BlockSize != 0 {
+		panic("NewSHA256FromState: length is not a multiple of the block size")
+	}
+
+	d := &sha256Digest{initH: h, initLen: length}
+	d.Reset()
+	return d
+}
+
+func (d *sha256Digest) Reset() {
+	d.h = d.initH
+	d.nx = 0
+	d.len = d.initLen
+}
+
+func (d *sha256Digest) Size() int { return sha256Size }
+
+func (d *sha256Digest) BlockSize() int { return sha256BlockSize }
+
+func (d *sha256Digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+	if d.nx > 0 {
+		copied := copy(d.x[d.nx:], p)
+		d.nx += copied
+		p = p[copied:]
+		if d.nx == sha256BlockSize {
+			d.block(d.x[:])
+			d.nx = 0
+		}
+	}
+
+	for len(p) >= sha256BlockSize {
+		d.block(p[:sha256BlockSize])
+		p = p[sha256BlockSize:]
+	}
+
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+	return n, nil
+}
+
+// Appends the hash of the data written so far to in, without changing the underlying state.
+func (d *sha256Digest) Sum(in []byte) []byte {
+	c := *d
+	c.Write(mdPadding(c.len, sha256BlockSize, binary.BigEndian))
+
+	var digest [sha256Size]byte
+	for i, h := range c.h {
+		binary.BigEndian.PutUint32(digest[4*i:], h)
+	}
+	return append(in, digest[:]...)
+}
+
+// Processes a single 64-byte block.
+func (d *sha256Digest) block(p []byte) {
+	var w [64]uint32
+	for i := 0; i < 16; i++ {
+		w[i] = binary.BigEndian.Uint32(p[4*i:])
+	}
+	for i := 16; i < 64; i++ {
+		s0 := bits.RotateLeft32(w[i-15], -7) ^ bits.RotateLeft32(w[i-15], -18) ^ (w[i-15] >> 3)
+		s1 := bits.RotateLeft32(w[i-2], -17) ^ bits.RotateLeft32(w[i-2], -19) ^ (w[i-2] >> 10)
+		w[i] = w[i-16] + s0 + w[i-7] + s1
+	}
+
+	a, b, c, dd, e, f, g, h := d.h[0], d.h[1], d.h[2], d.h[3], d.h[4], d.h[5], d.h[6], d.h[7]
+	for i := 0; i < 64; i++ {
+		s1 := bits.RotateLeft32(e, -6) ^ bits.RotateLeft32(e, -11) ^ bits.RotateLeft32(e, -25)
+		ch := (e & f) ^ (^e & g)
+		t1 := h + s1 + ch + sha256K[i] + w[i]
+		s0 := bits.RotateLeft32(a, -2) ^ bits.RotateLeft32(a, -13) ^ bits.RotateLeft32(a, -22)
+		maj := (a & b) ^ (a & c) ^ (b & c)
+		t2 := s0 + maj
+		a, b, c, dd, e, f, g, h = t1+t2, a, b, c, dd+t1, e, f, g
+	}
+
+	d.h[0] += a
+	d.h[1] += b
+	d.h[2] += c
+	d.h[3] += dd
+	d.h[4] += e
+	d.h[5] += f
+	d.h[6] += g
+	d.h[7] += h
+}
@@ -0,0 +1,20 @@
+package prng
+
+import (
+	"errors"
+	"time"
+)
+
+// Given the first output of an MT19937 which was seeded with a Unix timestamp between from and to (inclusive), returns
+// the seed, along with the number of candidate seeds tried. The most recent timestamps are tried first.
+func RecoverTimestampSeed(output uint32, from, to time.Time) (uint32, int, error) {
+	tried := 0
+	for t := to.Unix(); t >= from.Unix(); t-- {
+		tried++
+		seed := uint32(t)
+		if NewMT19937(seed).Uint32() == output {
+			return seed, tried, nil
+		}
+	}
+	return 0, tried, errors.New("RecoverTimestampSeed: no timestamp in the window produces the output")
+}
@@ -0,0 +1,29 @@
+package prng
+
+import "time"
+
+// A Clock tells the time and waits. It allows code which depends on the current time to be run against a simulated
+// clock instead of actually sleeping.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time        { return time.Now() }
+func (systemClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// SystemClock is the real wall clock.
+var SystemClock Clock = systemClock{}
+
+// A SimulatedClock is a Clock whose time only moves forward when Sleep is called, without actually sleeping.
+type SimulatedClock struct{ now time.Time }
+
+// Returns a SimulatedClock starting at a given time.
+func NewSimulatedClock(start time.Time) *SimulatedClock {
+	return &SimulatedClock{start}
+}
+
+func (c *SimulatedClock) Now() time.Time        { return c.now }
+func (c *SimulatedClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
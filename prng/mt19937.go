@@ -0,0 +1,112 @@
+package prng
+
+// This file provides a "from scratch" implementation of the 32-bit Mersenne Twister, MT19937, along with the tools to
+// clone a generator from its output.
+
+const (
+	mtN         = 624
+	mtM         = 397
+	mtMatrixA   = 0x9908b0df
+	mtUpperMask = 0x80000000
+	mtLowerMask = 0x7fffffff
+	mtF         = 1812433253
+
+	mtU = 11
+	mtS = 7
+	mtB = 0x9d2c5680
+	mtT = 15
+	mtC = 0xefc60000
+	mtL = 18
+)
+
+// MT19937 is a 32-bit Mersenne Twister.
+type MT19937 struct {
+	state [mtN]uint32
+	index int
+}
+
+// Returns a new MT19937 seeded with a given seed.
+func NewMT19937(seed uint32) *MT19937 {
+	mt := &MT19937{}
+	mt.Seed(seed)
+	return mt
+}
+
+// Resets the generator's state according to a given seed.
+func (mt *MT19937) Seed(seed uint32) {
+	mt.state[0] = seed
+	for i := 1; i < mtN; i++ {
+		mt.state[i] = mtF*(mt.state[i-1]^(mt.state[i-1]>>30)) + uint32(i)
+	}
+	mt.index = mtN
+}
+
+// Generates the next mtN words of state.
+func (mt *MT19937) twist() {
+	for i := 0; i < mtN; i++ {
+		y := (mt.state[i] & mtUpperMask) | (mt.state[(i+1)%mtN] & mtLowerMask)
+		next := mt.state[(i+mtM)%mtN] ^ (y >> 1)
+		if y&1 != 0 {
+			next ^= mtMatrixA
+		}
+		mt.state[i] = next
+	}
+	mt.index = 0
+}
+
+// Returns the next output of the generator.
+func (mt *MT19937) Uint32() uint32 {
+	if mt.index >= mtN {
+		mt.twist()
+	}
+
+	y := mt.state[mt.index]
+	mt.index++
+	return temper(y)
+}
+
+// Applies the MT19937 tempering transform to a word of state.
+func temper(y uint32) uint32 {
+	y ^= y >> mtU
+	y ^= (y << mtS) & mtB
+	y ^= (y << mtT) & mtC
+	y ^= y >> mtL
+	return y
+}
+
+// Inverts y ^= y >> shift.
+func undoRightShift(y uint32, shift uint) uint32 {
+	x := y
+	for i := shift; i < 32; i += shift {
+		x = y ^ (x >> shift)
+	}
+	return x
+}
+
+// Inverts y ^= (y << shift) & mask.
+func undoLeftShift(y uint32, shift uint, mask uint32) uint32 {
+	x := y
+	for i := shift; i < 32; i += shift {
+		x = y ^ ((x << shift) & mask)
+	}
+	return x
+}
+
+// Returns the word of state which the generator tempered to produce a given output.
+func Untemper(y uint32) uint32 {
+	y = undoRightShift(y, mtL)
+	y = undoLeftShift(y, mtT, mtC)
+	y = undoLeftShift(y, mtS, mtB)
+	y = undoRightShift(y, mtU)
+	return y
+}
+
+// Returns a generator whose future outputs match those of a generator which produced a given run of mtN consecutive
+// outputs.
+func Clone(outputs [mtN]uint32) *MT19937 {
+	mt := &MT19937{index: mtN}
+	for i, y := range outputs {
+		mt.state[i] = Untemper(y)
+	}
+	return mt
+}
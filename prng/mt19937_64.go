@@ -0,0 +1,113 @@
+package prng
+
+// This file provides a "from scratch" implementation of the 64-bit Mersenne Twister, MT19937-64, along with the tools
+// to clone a generator from its output.
+
+const (
+	mt64N         = 312
+	mt64M         = 156
+	mt64MatrixA   = 0xb5026f5aa96619e9
+	mt64UpperMask = 0xffffffff80000000
+	mt64LowerMask = 0x7fffffff
+	mt64F         = 6364136223846793005
+
+	mt64U = 29
+	mt64D = 0x5555555555555555
+	mt64S = 17
+	mt64B = 0x71d67fffeda60000
+	mt64T = 37
+	mt64C = 0xfff7eee000000000
+	mt64L = 43
+)
+
+// MT64 is a 64-bit Mersenne Twister, MT19937-64.
+type MT64 struct {
+	state [mt64N]uint64
+	index int
+}
+
+// Returns a new MT64 seeded with a given seed.
+func NewMT64(seed uint64) *MT64 {
+	mt := &MT64{}
+	mt.Seed(seed)
+	return mt
+}
+
+// Resets the generator's state according to a given seed.
+func (mt *MT64) Seed(seed uint64) {
+	mt.state[0] = seed
+	for i := 1; i < mt64N; i++ {
+		mt.state[i] = mt64F*(mt.state[i-1]^(mt.state[i-1]>>62)) + uint64(i)
+	}
+	mt.index = mt64N
+}
+
+// Generates the next mt64N words of state.
+func (mt *MT64) twist() {
+	for i := 0; i < mt64N; i++ {
+		y := (mt.state[i] & mt64UpperMask) | (mt.state[(i+1)%mt64N] & mt64LowerMask)
+		next := mt.state[(i+mt64M)%mt64N] ^ (y >> 1)
+		if y&1 != 0 {
+			next ^= mt64MatrixA
+		}
+		mt.state[i] = next
+	}
+	mt.index = 0
+}
+
+// Returns the next output of the generator.
+func (mt *MT64) Uint64() uint64 {
+	if mt.index >= mt64N {
+		mt.twist()
+	}
+
+	y := mt.state[mt.index]
+	mt.index++
+	return temper64(y)
+}
+
+// Applies the MT19937-64 tempering transform to a word of state.
+func temper64(y uint64) uint64 {
+	y ^= (y >> mt64U) & mt64D
+	y ^= (y << mt64S) & mt64B
+	y ^= (y << mt64T) & mt64C
+	y ^= y >> mt64L
+	return y
+}
+
+// Inverts y ^= (y >> shift) & mask.
+func undoRightShift64(y uint64, shift uint, mask uint64) uint64 {
+	x := y
+	for i := shift; i < 64; i += shift {
+		x = y ^ ((x >> shift) & mask)
+	}
+	return x
+}
+
+// Inverts y ^= (y << shift) & mask.
+func undoLeftShift64(y uint64, shift uint, mask uint64) uint64 {
+	x := y
+	for i := shift; i < 64; i += shift {
+		x = y ^ ((x << shift) & mask)
+	}
+	return x
+}
+
+// Returns the word of state which the generator tempered to produce a given output.
+func Untemper64(y uint64) uint64 {
+	y = undoRightShift64(y, mt64L, ^uint64(0))
+	y = undoLeftShift64(y, mt64T, mt64C)
+	y = undoLeftShift64(y, mt64S, mt64B)
+	y = undoRightShift64(y, mt64U, mt64D)
+	return y
+}
+
+// Returns a generator whose future outputs match those of a generator which produced a given run of mt64N consecutive
+// outputs.
+func Clone64(outputs [mt64N]uint64) *MT64 {
+	mt := &MT64{index: mt64N}
+	for i, y := range outputs {
+		mt.state[i] = Untemper64(y)
+	}
+	return mt
+}
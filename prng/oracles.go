@@ -0,0 +1,31 @@
+package prng
+
+import (
+	"crypto/rand"
+	"math/big"
+	"time"
+)
+
+// Generates a random int in the range [lo, hi) using crypto.rand.
+func randInt(lo, hi int) int {
+	if lo > hi {
+		panic("randInt: lo > hi")
+	}
+
+	bigRand, err := rand.Int(rand.Reader, big.NewInt(int64(hi-lo)))
+	if err != nil {
+		panic(err)
+	}
+
+	return int(bigRand.Int64()) + lo
+}
+
+// Waits a random number of seconds between 40 and 1000 according to clock, seeds an MT19937 with the current Unix
+// time, waits again, and returns the generator's first output. Also returns the seed that was used.
+func GetTimestampSeededOutput(clock Clock) (uint32, uint32) {
+	clock.Sleep(time.Duration(randInt(40, 1001)) * time.Second)
+	seed := uint32(clock.Now().Unix())
+	mt := NewMT19937(seed)
+	clock.Sleep(time.Duration(randInt(40, 1001)) * time.Second)
+	return mt.Uint32(), seed
+}
@@ -0,0 +1,158 @@
+package protocol
+
+import (
+	"cryptopals/pubkey/dh"
+	"math/big"
+	"sync"
+)
+
+// ParameterInjection is a man-in-the-middle against the DH echo protocol which replaces both public values with p, so
+// that both sides compute the shared secret p^x mod p = 0, and then decrypts every message.
+type ParameterInjection struct {
+	mu        sync.Mutex
+	p         *big.Int
+	recovered [][]byte
+}
+
+// Returns a new ParameterInjection. Its Intercept method is the middlebox to use on the network.
+func NewParameterInjection() *ParameterInjection {
+	return &ParameterInjection{}
+}
+
+// Rewrites the public values in the key exchange and records the plaintext of every encrypted message.
+func (pi *ParameterInjection) Intercept(m Message) []Message {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+
+	switch payload := m.Payload.(type) {
+	case DHParams:
+		pi.p = payload.P
+		m.Payload = DHParams{payload.P, payload.G, payload.P}
+	case DHPublic:
+		m.Payload = DHPublic{pi.p}
+	case Encrypted:
+		plaintext, err := decryptMessage(dh.SessionKey(big.NewInt(0)), payload.Data)
+		if err == nil {
+			pi.recovered = append(pi.recovered, plaintext)
+		}
+	}
+	return []Message{m}
+}
+
+// Returns the plaintexts of the encrypted messages seen so far, in order.
+func (pi *ParameterInjection) Recovered() [][]byte {
+	pi.mu.Lock()
+	defer pi.mu.Unlock()
+	return append([][]byte{}, pi.recovered...)
+}
+
+// MaliciousGenerator is a generator which a man-in-the-middle substitutes during group negotiation.
+type MaliciousGenerator int
+
+const (
+	// GeneratorOne replaces g with 1, so that the shared secret is 1.
+	GeneratorOne MaliciousGenerator = iota
+	// GeneratorP replaces g with p, so that the shared secret is 0.
+	GeneratorP
+	// GeneratorPMinusOne replaces g with p-1, so that the shared secret is 1 or p-1.
+	GeneratorPMinusOne
+)
+
+func (v MaliciousGenerator) String() string {
+	switch v {
+	case GeneratorOne:
+		return "g = 1"
+	case GeneratorP:
+		return "g = p"
+	case GeneratorPMinusOne:
+		return "g = p - 1"
+	default:
+		return "unknown generator"
+	}
+}
+
+// Returns the value of the malicious generator for a prime p.
+func (v MaliciousGenerator) value(p *big.Int) *big.Int {
+	switch v {
+	case GeneratorOne:
+		return big.NewInt(1)
+	case GeneratorP:
+		return new(big.Int).Set(p)
+	default:
+		return new(big.Int).Sub(p, big.NewInt(1))
+	}
+}
+
+// NegotiatedGroupMITM is a man-in-the-middle against the negotiated-group echo protocol which rewrites the proposed
+// generator, predicts the resulting shared secret, and decrypts every message.
+type NegotiatedGroupMITM struct {
+	mu         sync.Mutex
+	variant    MaliciousGenerator
+	p          *big.Int
+	publicKeys []*big.Int
+	recovered  [][]byte
+}
+
+// Returns a new NegotiatedGroupMITM which substitutes a given generator. Its Intercept method is the middlebox to use
+// on the network.
+func NewNegotiatedGroupMITM(variant MaliciousGenerator) *NegotiatedGroupMITM {
+	return &NegotiatedGroupMITM{variant: variant}
+}
+
+// Returns the shared secret which both sides compute after the generator has been replaced.
+func (mitm *NegotiatedGroupMITM) sharedSecret() *big.Int {
+	switch mitm.variant {
+	case GeneratorOne:
+		return big.NewInt(1)
+	case GeneratorP:
+		return big.NewInt(0)
+	default:
+		// (p-1)^x is p-1 for odd x and 1 for even x, so each public value reveals the parity of its exponent, and the
+		// shared secret (p-1)^(ab) is p-1 only if both exponents are odd.
+		if mitm.p == nil {
+			return big.NewInt(1)
+		}
+		pMinusOne := mitm.variant.value(mitm.p)
+		for _, y := range mitm.publicKeys {
+			if y.Cmp(pMinusOne) != 0 {
+				return big.NewInt(1)
+			}
+		}
+		return pMinusOne
+	}
+}
+
+// Rewrites the proposed generator, observes the public values, and records the plaintext of every encrypted message.
+func (mitm *NegotiatedGroupMITM) Intercept(m Message) []Message {
+	mitm.mu.Lock()
+	defer mitm.mu.Unlock()
+
+	switch payload := m.Payload.(type) {
+	case NegotiateGroup:
+		if payload.P == nil {
+			break
+		}
+		mitm.p = payload.P
+		m.Payload = NegotiateGroup{payload.P, mitm.variant.value(payload.P)}
+	case PublicKey:
+		mitm.publicKeys = append(mitm.publicKeys, payload.Y)
+	case Encrypted:
+		plaintext, err := decryptMessage(dh.SessionKey(mitm.sharedSecret()), payload.Data)
+		if err == nil {
+			mitm.recovered = append(mitm.recovered, plaintext)
+		}
+	}
+	return []Message{m}
+}
+
+// Returns the generator which was substituted.
+func (mitm *NegotiatedGroupMITM) Variant() MaliciousGenerator {
+	return mitm.variant
+}
+
+// Returns the plaintexts of the encrypted messages seen so far, in order.
+func (mitm *NegotiatedGroupMITM) Recovered() [][]byte {
+	mitm.mu.Lock()
+	defer mitm.mu.Unlock()
+	return append([][]byte{}, mitm.recovered...)
+}
@@ -0,0 +1,35 @@
+package protocol
+
+import (
+	"crypto/rand"
+	"cryptopals/block"
+	"errors"
+)
+
+// The size of an AES-CBC IV, which is appended to each encrypted message.
+const ivSize = 16
+
+// Encrypts a message with AES-128-CBC under a given key and a random IV, and returns the ciphertext with the IV
+// appended.
+func encryptMessage(key, message []byte) ([]byte, error) {
+	iv := make([]byte, ivSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := block.EncryptAESCBCBytes(message, key, iv)
+	if err != nil {
+		return nil, err
+	}
+	return append(ciphertext, iv...), nil
+}
+
+// Decrypts a ciphertext with an appended IV, as produced by encryptMessage.
+func decryptMessage(key, data []byte) ([]byte, error) {
+	if len(data) < ivSize {
+		return nil, errors.New("decryptMessage: message is shorter than an IV")
+	}
+
+	ciphertext, iv := data[:len(data)-ivSize], data[len(data)-ivSize:]
+	return block.DecryptAESCBCBytes(ciphertext, key, iv)
+}
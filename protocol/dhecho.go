@@ -0,0 +1,216 @@
+package protocol
+
+import (
+	"bytes"
+	"cryptopals/pubkey/dh"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// This file provides a Diffie-Hellman echo protocol: Alice sends the group parameters and her public value, Bob
+// replies with his, and then Alice sends AES-CBC encrypted messages which Bob decrypts and sends back re-encrypted.
+
+// Names of the participants.
+const (
+	Alice = "Alice"
+	Bob   = "Bob"
+)
+
+// DHParams carries the group parameters and the initiator's public value.
+type DHParams struct {
+	P, G, A *big.Int
+}
+
+// DHPublic carries the responder's public value.
+type DHPublic struct {
+	B *big.Int
+}
+
+// Encrypted carries an AES-CBC ciphertext with the IV appended.
+type Encrypted struct {
+	Data []byte
+}
+
+// Done tells the responder that the conversation is over.
+type Done struct{}
+
+// Returns an error for a message whose payload is not what the protocol expects at this point.
+func unexpected(who string, m Message) error {
+	return fmt.Errorf("%s: unexpected %T from %s", who, m.Payload, m.From)
+}
+
+// Returns an error for a message whose payload is missing a value, as a middlebox might send.
+func incomplete(who string, m Message) error {
+	return fmt.Errorf("%s: incomplete %T from %s", who, m.Payload, m.From)
+}
+
+// Sends each message to Bob encrypted under sessionKey and checks that Bob echoes it back, then tells Bob that the
+// conversation is over. Returns the echoed messages.
+func echoAlice(network *Network, sessionKey []byte, messages [][]byte) ([][]byte, error) {
+	var echoed [][]byte
+	for _, message := range messages {
+		data, err := encryptMessage(sessionKey, message)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := network.Send(Message{Alice, Bob, Encrypted{data}}); err != nil {
+			return nil, err
+		}
+
+		m, err := network.Receive(Alice)
+		if err != nil {
+			return nil, err
+		}
+
+		reply, ok := m.Payload.(Encrypted)
+		if !ok {
+			return nil, unexpected(Alice, m)
+		}
+
+		echo, err := decryptMessage(sessionKey, reply.Data)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(echo, message) {
+			return nil, fmt.Errorf("%s: echo %q does not match %q", Alice, echo, message)
+		}
+		echoed = append(echoed, echo)
+	}
+	return echoed, network.Send(Message{Alice, Bob, Done{}})
+}
+
+// Decrypts each message from Alice under sessionKey and sends it back re-encrypted, until Alice is done.
+func echoBob(network *Network, sessionKey []byte) error {
+	for {
+		m, err := network.Receive(Bob)
+		if err != nil {
+			return err
+		}
+
+		switch payload := m.Payload.(type) {
+		case Done:
+			return nil
+		case Encrypted:
+			message, err := decryptMessage(sessionKey, payload.Data)
+			if err != nil {
+				return err
+			}
+
+			data, err := encryptMessage(sessionKey, message)
+			if err != nil {
+				return err
+			}
+
+			if err := network.Send(Message{Bob, Alice, Encrypted{data}}); err != nil {
+				return err
+			}
+		default:
+			return unexpected(Bob, m)
+		}
+	}
+}
+
+// Runs Alice's side of the echo protocol, sending each message and checking that Bob echoes it back. Returns the
+// echoed messages.
+func dhEchoAlice(network *Network, group *dh.Group, messages [][]byte) ([][]byte, error) {
+	key, err := dh.GenerateKey(group)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := network.Send(Message{Alice, Bob, DHParams{group.P, group.G, key.Public}}); err != nil {
+		return nil, err
+	}
+
+	m, err := network.Receive(Alice)
+	if err != nil {
+		return nil, err
+	}
+
+	public, ok := m.Payload.(DHPublic)
+	if !ok {
+		return nil, unexpected(Alice, m)
+	}
+
+	if public.B == nil {
+		return nil, incomplete(Alice, m)
+	}
+	return echoAlice(network, dh.SessionKey(key.SharedSecret(public.B)), messages)
+}
+
+// Runs Bob's side of the echo protocol, echoing messages until Alice is done.
+func dhEchoBob(network *Network) error {
+	m, err := network.Receive(Bob)
+	if err != nil {
+		return err
+	}
+
+	params, ok := m.Payload.(DHParams)
+	if !ok {
+		return unexpected(Bob, m)
+	}
+
+	if params.P == nil || params.G == nil || params.A == nil {
+		return incomplete(Bob, m)
+	}
+
+	key, err := dh.GenerateKey(&dh.Group{P: params.P, G: params.G})
+	if err != nil {
+		return err
+	}
+
+	if err := network.Send(Message{Bob, Alice, DHPublic{key.Public}}); err != nil {
+		return err
+	}
+	return echoBob(network, dh.SessionKey(key.SharedSecret(params.A)))
+}
+
+// Runs Alice and Bob, each in its own goroutine, and returns Alice's result once both are done. The first side to fail
+// closes the network so that the other stops waiting, and its error is the one returned.
+func runAliceAndBob(network *Network, alice func() ([][]byte, error), bob func() error) ([][]byte, error) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var echoed [][]byte
+	var firstErr error
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			network.Close()
+		}
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		var err error
+		if echoed, err = alice(); err != nil {
+			fail(err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if err := bob(); err != nil {
+			fail(err)
+		}
+	}()
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return echoed, nil
+}
+
+// Runs the echo protocol between Alice and Bob, each in its own goroutine, and returns the messages Bob echoed back to
+// Alice.
+func RunDHEcho(network *Network, group *dh.Group, messages [][]byte) ([][]byte, error) {
+	return runAliceAndBob(network,
+		func() ([][]byte, error) { return dhEchoAlice(network, group, messages) },
+		func() error { return dhEchoBob(network) },
+	)
+}
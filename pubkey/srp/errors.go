@@ -0,0 +1,21 @@
+package srp
+
+import "errors"
+
+// Sentinel errors returned by the SRP client and server. Callers should compare against these using errors.Is, since
+// they are usually wrapped with the name of the function that produced them.
+var (
+	// ErrUnknownUser indicates that no verifier is registered for a username.
+	ErrUnknownUser = errors.New("unknown user")
+	// ErrUserExists indicates that a verifier is already registered for a username.
+	ErrUserExists = errors.New("user already exists")
+	// ErrInvalidPublicKey indicates that a public value A or B is missing or zero modulo N, or that the scrambling
+	// parameter u is zero.
+	ErrInvalidPublicKey = errors.New("invalid public value")
+	// ErrUnknownSession indicates that a proof was sent for a session which does not exist or has already been used.
+	ErrUnknownSession = errors.New("unknown session")
+	// ErrAuthenticationFailed indicates that the server rejected the client's proof.
+	ErrAuthenticationFailed = errors.New("authentication failed")
+	// ErrInvalidServerProof indicates that the client rejected the server's proof.
+	ErrInvalidServerProof = errors.New("invalid server proof")
+)
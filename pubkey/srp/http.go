@@ -0,0 +1,203 @@
+package srp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+)
+
+// This file exposes an SRP server over HTTP, with JSON requests and responses in which integers and byte strings are
+// hex-encoded, along with an Authenticator which talks to it.
+
+type helloRequest struct {
+	Username string `json:"username"`
+	A        string `json:"A"`
+}
+
+type challengeResponse struct {
+	Session string `json:"session"`
+	Salt    string `json:"salt"`
+	B       string `json:"B"`
+}
+
+type proofMessage struct {
+	Session string `json:"session,omitempty"`
+	Proof   string `json:"proof"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// The sentinel errors which can cross the wire, along with their status codes and wire names.
+var wireErrors = []struct {
+	err    error
+	status int
+	name   string
+}{
+	{ErrUnknownUser, http.StatusNotFound, "unknown_user"},
+	{ErrInvalidPublicKey, http.StatusBadRequest, "invalid_public_key"},
+	{ErrUnknownSession, http.StatusNotFound, "unknown_session"},
+	{ErrAuthenticationFailed, http.StatusUnauthorized, "authentication_failed"},
+}
+
+// Writes err as a JSON error response, using the status code and name of the sentinel error it wraps.
+func writeError(w http.ResponseWriter, err error) {
+	status, name := http.StatusInternalServerError, "internal_error"
+	for _, e := range wireErrors {
+		if errors.Is(err, e.err) {
+			status, name = e.status, e.name
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{name})
+}
+
+// Writes v as a JSON response with status 200 OK.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// Returns a handler which serves a given Server at POST /hello and POST /verify.
+func NewHandler(server *Server) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hello", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req helloRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "malformed request", http.StatusBadRequest)
+			return
+		}
+
+		public, ok := new(big.Int).SetString(req.A, 16)
+		if !ok {
+			http.Error(w, "malformed public value", http.StatusBadRequest)
+			return
+		}
+
+		challenge, err := server.Hello(req.Username, public)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, challengeResponse{
+			Session: challenge.Session,
+			Salt:    hex.EncodeToString(challenge.Salt),
+			B:       challenge.B.Text(16),
+		})
+	})
+	mux.HandleFunc("/verify", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req proofMessage
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "malformed request", http.StatusBadRequest)
+			return
+		}
+
+		proof, err := hex.DecodeString(req.Proof)
+		if err != nil {
+			http.Error(w, "malformed proof", http.StatusBadRequest)
+			return
+		}
+
+		serverProof, err := server.Verify(req.Session, proof)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, proofMessage{Proof: hex.EncodeToString(serverProof)})
+	})
+	return mux
+}
+
+// Returns a new localhost HTTP server serving a given Server. The caller should close it when done.
+func NewHTTPServer(server *Server) *httptest.Server {
+	return httptest.NewServer(NewHandler(server))
+}
+
+// An HTTPAuthenticator is an Authenticator for a Server served by NewHandler at a given base URL.
+type HTTPAuthenticator struct {
+	URL    string
+	Client *http.Client
+}
+
+// Posts req as JSON to a given path and decodes the response into resp, translating error responses back into the
+// sentinel errors they came from.
+func (h *HTTPAuthenticator) post(path string, req, resp interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	r, err := client.Post(h.URL+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		var e errorResponse
+		json.NewDecoder(r.Body).Decode(&e)
+		for _, w := range wireErrors {
+			if e.Error == w.name {
+				return fmt.Errorf("HTTPAuthenticator: %w", w.err)
+			}
+		}
+		return fmt.Errorf("HTTPAuthenticator: %s returned %s", path, r.Status)
+	}
+	return json.NewDecoder(r.Body).Decode(resp)
+}
+
+// Sends a hello to the server.
+func (h *HTTPAuthenticator) Hello(username string, public *big.Int) (*Challenge, error) {
+	if public == nil {
+		return nil, fmt.Errorf("HTTPAuthenticator: %w", ErrInvalidPublicKey)
+	}
+
+	var resp challengeResponse
+	if err := h.post("/hello", helloRequest{username, public.Text(16)}, &resp); err != nil {
+		return nil, err
+	}
+
+	salt, err := hex.DecodeString(resp.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	serverPublic, ok := new(big.Int).SetString(resp.B, 16)
+	if !ok {
+		return nil, errors.New("HTTPAuthenticator: malformed public value")
+	}
+	return &Challenge{resp.Session, salt, serverPublic}, nil
+}
+
+// Sends the client's proof to the server.
+func (h *HTTPAuthenticator) Verify(session string, proof []byte) ([]byte, error) {
+	var resp proofMessage
+	if err := h.post("/verify", proofMessage{session, hex.EncodeToString(proof)}, &resp); err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(resp.Proof)
+}
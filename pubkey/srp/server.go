@@ -0,0 +1,154 @@
+package srp
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"cryptopals/pubkey/dh"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// This file provides an SRP server which stores a salt and verifier for each user and answers login attempts.
+
+const saltSize = 16
+
+// A Challenge is the server's answer to a client's hello: a handle for the login attempt, the user's salt, and the
+// server's public value B.
+type Challenge struct {
+	Session string
+	Salt    []byte
+	B       *big.Int
+}
+
+// An Authenticator is an SRP server as seen by a client, whether in-process or over the network.
+type Authenticator interface {
+	// Hello starts a login attempt for a username with the client's public value A.
+	Hello(username string, public *big.Int) (*Challenge, error)
+	// Verify checks the client's proof for a login attempt and returns the server's proof.
+	Verify(session string, proof []byte) ([]byte, error)
+}
+
+type record struct {
+	salt     []byte
+	verifier *big.Int
+}
+
+type serverSession struct {
+	salt   []byte
+	public *big.Int
+	key    []byte
+}
+
+// Mode selects whether a Server validates the client's public value.
+type Mode int
+
+const (
+	// Hardened rejects a client public value A which is zero modulo N, as RFC 5054 requires.
+	Hardened Mode = iota
+	// Vulnerable accepts any client public value, so that a client which sends a multiple of N can predict the session
+	// key without knowing the password.
+	Vulnerable
+)
+
+// A Server is an in-process SRP server. It is safe for concurrent use.
+type Server struct {
+	params   *Params
+	mode     Mode
+	mu       sync.Mutex
+	users    map[string]record
+	sessions map[string]serverSession
+}
+
+// Returns a new hardened Server with no registered users.
+func NewServer(params *Params) *Server {
+	return NewServerWithMode(params, Hardened)
+}
+
+// Returns a new Server in a given mode with no registered users.
+func NewServerWithMode(params *Params, mode Mode) *Server {
+	return &Server{
+		params:   params,
+		mode:     mode,
+		users:    make(map[string]record),
+		sessions: make(map[string]serverSession),
+	}
+}
+
+// Registers a user under a random salt, storing only the salt and verifier.
+func (s *Server) Register(username string, password []byte) error {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	verifier := Verifier(s.params, salt, username, password)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[username]; ok {
+		return fmt.Errorf("Register: %w", ErrUserExists)
+	}
+	s.users[username] = record{salt, verifier}
+	return nil
+}
+
+// Computes B = kv + g^b mod N and the session key K = H((A * v^u)^b mod N) for a login attempt.
+func (s *Server) Hello(username string, public *big.Int) (*Challenge, error) {
+	s.mu.Lock()
+	user, ok := s.users[username]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("Hello: %w", ErrUnknownUser)
+	}
+
+	if public == nil || s.mode == Hardened && s.params.isZero(public) {
+		return nil, fmt.Errorf("Hello: %w", ErrInvalidPublicKey)
+	}
+
+	b, err := s.params.randomExponent()
+	if err != nil {
+		return nil, err
+	}
+
+	n := s.params.N
+	serverPublic := new(big.Int).Mul(s.params.K, user.verifier)
+	serverPublic.Add(serverPublic, dh.ModExp(s.params.G, b, n)).Mod(serverPublic, n)
+
+	u := s.params.hashInts(public, serverPublic)
+	if u.Sign() == 0 {
+		return nil, fmt.Errorf("Hello: %w", ErrInvalidPublicKey)
+	}
+
+	secret := new(big.Int).Mul(public, dh.ModExp(user.verifier, u, n))
+	secret = dh.ModExp(secret, b, n)
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	session := hex.EncodeToString(id)
+
+	s.mu.Lock()
+	s.sessions[session] = serverSession{user.salt, public, sessionKey(secret)}
+	s.mu.Unlock()
+
+	return &Challenge{session, append([]byte{}, user.salt...), serverPublic}, nil
+}
+
+// Checks the client's proof for a login attempt and, if it is valid, returns the server's proof. Each session can be
+// verified only once.
+func (s *Server) Verify(session string, proof []byte) ([]byte, error) {
+	s.mu.Lock()
+	state, ok := s.sessions[session]
+	delete(s.sessions, session)
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("Verify: %w", ErrUnknownSession)
+	}
+
+	if subtle.ConstantTimeCompare(clientProof(state.key, state.salt), proof) != 1 {
+		return nil, fmt.Errorf("Verify: %w", ErrAuthenticationFailed)
+	}
+	return s.params.serverProof(state.key, state.public, proof), nil
+}
@@ -0,0 +1,103 @@
+package srp
+
+import (
+	"crypto/rand"
+	"cryptopals/mac"
+	"cryptopals/pubkey/dh"
+	"math/big"
+)
+
+// This file provides the parameters and derived values shared by the SRP-6a (RFC 5054) client and server. All hashes
+// are SHA-256 and the proofs of the session key are HMAC-SHA256, both from the mac package.
+
+// Params are the group N, g shared by the client and server, along with the multiplier k = H(N | PAD(g)).
+type Params struct {
+	N, G, K *big.Int
+}
+
+// Returns the SRP parameters for a given Diffie-Hellman group.
+func NewParams(group *dh.Group) *Params {
+	p := &Params{N: group.P, G: group.G}
+	p.K = p.hashInts(p.N, p.G)
+	return p
+}
+
+// Returns the SRP parameters for the 1536-bit MODP group.
+func DefaultParams() *Params {
+	return NewParams(dh.MODP1536())
+}
+
+// Returns the big-endian bytes of x, left-padded with zeros to the length of N. Values longer than N, which only an
+// unvalidated public value can be, are returned unpadded.
+func (p *Params) pad(x *big.Int) []byte {
+	b := x.Bytes()
+	size := (p.N.BitLen() + 7) / 8
+	if len(b) > size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[len(padded)-len(b):], b)
+	return padded
+}
+
+// Returns H(PAD(x1) | PAD(x2) | ...) as an integer.
+func (p *Params) hashInts(xs ...*big.Int) *big.Int {
+	h := mac.NewSHA256()
+	for _, x := range xs {
+		h.Write(p.pad(x))
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// Returns a random exponent in [1, N-1).
+func (p *Params) randomExponent() (*big.Int, error) {
+	x, err := rand.Int(rand.Reader, new(big.Int).Sub(p.N, big.NewInt(2)))
+	if err != nil {
+		return nil, err
+	}
+	return x.Add(x, big.NewInt(1)), nil
+}
+
+// Returns true iff x is zero modulo N.
+func (p *Params) isZero(x *big.Int) bool {
+	return new(big.Int).Mod(x, p.N).Sign() == 0
+}
+
+// Returns the private key x = H(salt | H(username | ":" | password)).
+func privateKey(salt []byte, username string, password []byte) *big.Int {
+	inner := mac.NewSHA256()
+	inner.Write([]byte(username + ":"))
+	inner.Write(password)
+
+	outer := mac.NewSHA256()
+	outer.Write(salt)
+	outer.Write(inner.Sum(nil))
+	return new(big.Int).SetBytes(outer.Sum(nil))
+}
+
+// Returns the verifier v = g^x mod N which the server stores for a user.
+func Verifier(params *Params, salt []byte, username string, password []byte) *big.Int {
+	return dh.ModExp(params.G, privateKey(salt, username, password), params.N)
+}
+
+// Returns the session key K = H(S).
+func sessionKey(secret *big.Int) []byte {
+	h := mac.NewSHA256()
+	h.Write(secret.Bytes())
+	return h.Sum(nil)
+}
+
+// Returns the client's proof of K, HMAC-SHA256(K, salt).
+func clientProof(key, salt []byte) []byte {
+	h := mac.NewHMAC(mac.NewSHA256, key)
+	h.Write(salt)
+	return h.Sum(nil)
+}
+
+// Returns the server's proof of K, HMAC-SHA256(K, PAD(A) | M), where M is the client's proof.
+func (p *Params) serverProof(key []byte, public *big.Int, proof []byte) []byte {
+	h := mac.NewHMAC(mac.NewSHA256, key)
+	h.Write(p.pad(public))
+	h.Write(proof)
+	return h.Sum(nil)
+}
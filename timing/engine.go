@@ -0,0 +1,212 @@
+package timing
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// This file provides a statistical engine for timing attacks: given a set of candidates and a probe which measures how
+// long some operation takes for a candidate, it decides which candidate is the slowest, sampling repeatedly so that
+// small leaks can be told apart from noise.
+
+// Statistic selects how an Engine summarizes the samples for a candidate.
+type Statistic int
+
+const (
+	// Median summarizes samples by their median.
+	Median Statistic = iota
+	// TrimmedMean summarizes samples by their mean after discarding Engine.Trim of them from each end.
+	TrimmedMean
+)
+
+// An Engine decides which candidate makes a probe take the longest.
+type Engine struct {
+	// Samples is the number of samples taken per candidate in each round.
+	Samples int
+	// Statistic is how the samples for a candidate are summarized.
+	Statistic Statistic
+	// Trim is the fraction of samples discarded from each end by TrimmedMean.
+	Trim float64
+	// OutlierFactor rejects samples further than OutlierFactor median absolute deviations from the median before
+	// summarizing. Zero disables outlier rejection.
+	OutlierFactor float64
+	// Separation is how many standard errors the best candidate must lead the runner-up by before the engine stops
+	// re-sampling. Zero disables adaptive re-sampling.
+	Separation float64
+	// Contenders is the number of leading candidates which are re-sampled when the result is not yet clear.
+	Contenders int
+	// MaxSamples caps the number of samples taken for any one candidate.
+	MaxSamples int
+}
+
+// Returns an Engine with defaults suitable for leaks of a few milliseconds over a local network.
+func NewEngine() *Engine {
+	return &Engine{
+		Samples:       5,
+		Statistic:     Median,
+		Trim:          0.1,
+		OutlierFactor: 3,
+		Separation:    3,
+		Contenders:    4,
+		MaxSamples:    100,
+	}
+}
+
+// Result describes the outcome of Engine.Slowest.
+type Result struct {
+	// Index is the index of the slowest candidate.
+	Index int
+	// Score and RunnerUp are the summarized timings of the slowest candidate and the next slowest.
+	Score, RunnerUp time.Duration
+	// Probes is the total number of times the probe was called.
+	Probes int
+}
+
+type candidateSamples struct {
+	index   int
+	samples []time.Duration
+	score   time.Duration
+	spread  float64
+}
+
+// Returns the median of sorted durations.
+func median(sorted []time.Duration) time.Duration {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// Returns the median absolute deviation of sorted durations about a given median.
+func medianAbsoluteDeviation(sorted []time.Duration, m time.Duration) time.Duration {
+	deviations := make([]time.Duration, len(sorted))
+	for i, d := range sorted {
+		deviations[i] = d - m
+		if deviations[i] < 0 {
+			deviations[i] = -deviations[i]
+		}
+	}
+	sort.Slice(deviations, func(i, j int) bool { return deviations[i] < deviations[j] })
+	return median(deviations)
+}
+
+// Rejects outliers and updates the candidate's score and spread (an estimate of the standard error of its score).
+func (e *Engine) summarize(c *candidateSamples) {
+	sorted := append([]time.Duration{}, c.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	m := median(sorted)
+	mad := medianAbsoluteDeviation(sorted, m)
+	if e.OutlierFactor > 0 && mad > 0 {
+		limit := time.Duration(e.OutlierFactor * float64(mad))
+		var kept []time.Duration
+		for _, d := range sorted {
+			if d >= m-limit && d <= m+limit {
+				kept = append(kept, d)
+			}
+		}
+		if len(kept) > 0 {
+			sorted = kept
+		}
+	}
+
+	switch e.Statistic {
+	case TrimmedMean:
+		k := int(e.Trim * float64(len(sorted)))
+		trimmed := sorted[k : len(sorted)-k]
+		if len(trimmed) == 0 {
+			trimmed = sorted
+		}
+		var sum time.Duration
+		for _, d := range trimmed {
+			sum += d
+		}
+		c.score = sum / time.Duration(len(trimmed))
+	default:
+		c.score = median(sorted)
+	}
+
+	// 1.4826 * MAD estimates the standard deviation of normally distributed samples.
+	c.spread = 1.4826 * float64(mad) / math.Sqrt(float64(len(sorted)))
+}
+
+// Takes n more samples for each of the given candidates, interleaving them so that drift affects all candidates alike.
+func (e *Engine) sample(cs []*candidateSamples, candidates [][]byte, probe func([]byte) time.Duration, n int) int {
+	probes := 0
+	for i := 0; i < n; i++ {
+		for _, c := range cs {
+			c.samples = append(c.samples, probe(candidates[c.index]))
+			probes++
+		}
+	}
+	for _, c := range cs {
+		e.summarize(c)
+	}
+	return probes
+}
+
+// Returns the candidate which makes probe take the longest, re-sampling the leading candidates until the winner is
+// clear or MaxSamples is reached.
+func (e *Engine) Slowest(candidates [][]byte, probe func([]byte) time.Duration) (Result, error) {
+	if len(candidates) == 0 {
+		return Result{}, errors.New("Engine: no candidates")
+	}
+
+	if e.Samples < 1 {
+		return Result{}, errors.New("Engine: Samples must be positive")
+	}
+
+	if e.Trim < 0 || e.Trim >= 0.5 {
+		return Result{}, errors.New("Engine: Trim must be in [0, 0.5)")
+	}
+
+	if e.OutlierFactor < 0 {
+		return Result{}, errors.New("Engine: OutlierFactor must not be negative")
+	}
+
+	if e.Separation < 0 {
+		return Result{}, errors.New("Engine: Separation must not be negative")
+	}
+
+	if e.MaxSamples < 0 {
+		return Result{}, errors.New("Engine: MaxSamples must not be negative")
+	}
+
+	cs := make([]*candidateSamples, len(candidates))
+	for i := range cs {
+		cs[i] = &candidateSamples{index: i}
+	}
+
+	probes := e.sample(cs, candidates, probe, e.Samples)
+	byScore := func() {
+		sort.SliceStable(cs, func(i, j int) bool { return cs[i].score > cs[j].score })
+	}
+	byScore()
+
+	for e.Separation > 0 && len(cs) > 1 && len(cs[0].samples) < e.MaxSamples {
+		lead := float64(cs[0].score - cs[1].score)
+		noise := math.Sqrt(cs[0].spread*cs[0].spread + cs[1].spread*cs[1].spread)
+		if lead > e.Separation*noise {
+			break
+		}
+
+		contenders := e.Contenders
+		if contenders < 2 {
+			contenders = 2
+		}
+		if contenders > len(cs) {
+			contenders = len(cs)
+		}
+		probes += e.sample(cs[:contenders], candidates, probe, e.Samples)
+		byScore()
+	}
+
+	result := Result{Index: cs[0].index, Score: cs[0].score, Probes: probes}
+	if len(cs) > 1 {
+		result.RunnerUp = cs[1].score
+	}
+	return result, nil
+}